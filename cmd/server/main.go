@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -19,10 +20,19 @@ func main() {
 
 	store := inmem.NewLobbyStore()
 	service := usecase.NewLobbyService(store)
+	if dir := os.Getenv("DECKS_DIR"); dir != "" {
+		if err := service.Decks().LoadDir(dir); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("decks: %v", service.Decks().Names())
+	}
 	wsServer := ws.NewServer(service)
+	go usecase.NewJanitor(service, usecase.DefaultJanitorConfig()).Run(context.Background())
 
 	mux := http.NewServeMux()
 	mux.Handle("/healthz", httpapi.HealthHandler())
+	mux.Handle("GET /replays/{code}", httpapi.ReplayHandler(service))
+	mux.Handle("GET /replays/{code}/turns/{turn}", httpapi.ReplayTurnHandler(service))
 	mux.Handle("/ws", wsServer.Handler())
 
 	addr := ":" + port
@@ -0,0 +1,107 @@
+// Command simulate plays bot-only games in-process and prints balance
+// statistics per player count and deck.
+//
+//	simulate -games 2000 -players 3-8 -decks classic,tricks -format csv
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"game-server/internal/bot"
+	"game-server/internal/domain"
+	"game-server/internal/sim"
+	"game-server/internal/usecase"
+)
+
+func main() {
+	games := flag.Int("games", 1000, "games per player count and deck")
+	players := flag.String("players", "3-8", "player counts, e.g. 3-8 or 4,6")
+	decks := flag.String("decks", "classic", "comma-separated deck names from the built-in catalog and -decks-dir")
+	decksDir := flag.String("decks-dir", os.Getenv("DECKS_DIR"), "directory of extra JSON or YAML deck specs")
+	rulesFile := flag.String("rules", "", "JSON file with the game rules; defaults to domain.DefaultRules")
+	difficulty := flag.String("difficulty", string(bot.Normal), "bot difficulty: easy, normal or hard")
+	seed := flag.Uint64("seed", 1, "base seed; the same flags and seed give the same report")
+	format := flag.String("format", "table", "output format: table or csv")
+	flag.Parse()
+
+	cfg := sim.Config{Games: *games, Difficulty: bot.Difficulty(*difficulty), Seed: *seed, Rules: domain.DefaultRules()}
+	var err error
+	if cfg.Players, err = parsePlayers(*players); err != nil {
+		log.Fatal(err)
+	}
+	if *rulesFile != "" {
+		data, err := os.ReadFile(*rulesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &cfg.Rules); err != nil {
+			log.Fatalf("%s: %v", *rulesFile, err)
+		}
+	}
+	// Let the simulator cover player counts outside the configured table size.
+	for _, n := range cfg.Players {
+		cfg.Rules.MinPlayers = min(cfg.Rules.MinPlayers, n)
+		cfg.Rules.MaxPlayers = max(cfg.Rules.MaxPlayers, n)
+	}
+
+	catalog := usecase.NewDeckCatalog()
+	if *decksDir != "" {
+		if err := catalog.LoadDir(*decksDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, name := range strings.Split(*decks, ",") {
+		d, ok := catalog.Get(strings.TrimSpace(name))
+		if !ok {
+			log.Fatalf("unknown deck %q (have %v)", name, catalog.Names())
+		}
+		cfg.Decks = append(cfg.Decks, d)
+	}
+
+	rows, err := sim.Run(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch *format {
+	case "table":
+		err = sim.WriteTable(os.Stdout, rows)
+	case "csv":
+		err = sim.WriteCSV(os.Stdout, rows)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parsePlayers accepts a range ("3-8") or a list ("4,6").
+func parsePlayers(s string) ([]int, error) {
+	if lo, hi, ok := strings.Cut(s, "-"); ok {
+		a, err1 := strconv.Atoi(lo)
+		b, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil || a < 1 || b < a {
+			return nil, fmt.Errorf("invalid player range %q", s)
+		}
+		var out []int
+		for n := a; n <= b; n++ {
+			out = append(out, n)
+		}
+		return out, nil
+	}
+	var out []int
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid player count %q", part)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
@@ -2,4 +2,7 @@ module game-server
 
 go 1.22.2
 
-require nhooyr.io/websocket v1.8.17
+require (
+	gopkg.in/yaml.v3 v3.0.1
+	nhooyr.io/websocket v1.8.17
+)
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"fmt"
+
+	"game-server/internal/domain"
+)
+
+// ActionType is a move a bot can make.
+type ActionType string
+
+const (
+	PlayCard    ActionType = "play_card"
+	Discard     ActionType = "discard_card"
+	Pass        ActionType = "pass"
+	CallOver    ActionType = "call_over"
+	CallMeeting ActionType = "call_meeting"
+	Vote        ActionType = "vote" // TargetID empty skips
+)
+
+// Action is what a Strategy decided to do.
+type Action struct {
+	Type      ActionType
+	HandIndex int
+	TargetID  string
+}
+
+// Apply makes the bot's move on g, or any legal move if the strategy got it
+// wrong: a confused strategy must not stall the table.
+func Apply(g *domain.Game, botID string, a Action) error {
+	if err := apply(g, botID, a); err != nil {
+		return fallback(g, botID)
+	}
+	return nil
+}
+
+func apply(g *domain.Game, botID string, a Action) error {
+	switch a.Type {
+	case PlayCard:
+		return g.PlayCard(botID, a.HandIndex, a.TargetID)
+	case Discard:
+		return g.DiscardCard(botID, a.HandIndex)
+	case Pass:
+		return g.PassTurn(botID)
+	case CallOver:
+		return g.CallOver(botID)
+	case CallMeeting:
+		return g.CallMeeting(botID)
+	case Vote:
+		if err := g.CastVote(botID, a.TargetID); err != nil {
+			return err
+		}
+		if g.MeetingComplete() {
+			return g.CloseMeeting()
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown bot action %q", a.Type)
+}
+
+// fallback makes any legal move for the bot.
+func fallback(g *domain.Game, botID string) error {
+	if g.Status == domain.GameStatusMeeting {
+		return apply(g, botID, Action{Type: Vote})
+	}
+	view, err := g.ViewFor(botID, "")
+	if err != nil {
+		return err
+	}
+	for i := range view.You.Hand {
+		if g.PlayCard(botID, i, "") == nil {
+			return nil
+		}
+		for _, p := range view.Players {
+			if g.PlayCard(botID, i, p.ID) == nil {
+				return nil
+			}
+		}
+	}
+	return g.PassTurn(botID)
+}
@@ -0,0 +1,84 @@
+// Package bot provides strategies for server-side bot players.
+//
+// A Brain implements usecase.BotBrain: it keeps a Memory of what it saw
+// through its own views and asks a Strategy for moves. Bots only ever see
+// what a human in the same seat would see.
+package bot
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"game-server/internal/domain"
+	"game-server/internal/usecase"
+)
+
+// Difficulty selects a built-in strategy.
+type Difficulty string
+
+const (
+	Easy   Difficulty = "easy"   // random legal moves
+	Normal Difficulty = "normal" // plays for its team
+	Hard   Difficulty = "hard"   // plays for its team and covers its tracks
+)
+
+var ErrUnknownDifficulty = errors.New("unknown bot difficulty")
+
+// Strategy picks a bot's next move from its view and memory, or returns
+// false when it has nothing to do. Whether it wants to move must depend on
+// the view and memory only: the lobby asks once to schedule the bot and
+// again when the move is due.
+type Strategy interface {
+	Act(view domain.GameView, mem *Memory) (Action, bool)
+}
+
+// Brain pairs a Strategy with the Memory it reasons about.
+type Brain struct {
+	strategy Strategy
+	mem      Memory
+}
+
+// NewBrain returns a brain driven by s.
+func NewBrain(s Strategy) *Brain {
+	return &Brain{strategy: s}
+}
+
+// New returns a brain with the built-in strategy for d; an empty difficulty
+// means Normal.
+func New(d Difficulty) (*Brain, error) {
+	return NewSeeded(d, rand.Uint64())
+}
+
+// NewSeeded is New with a fixed seed, for reproducible simulations.
+func NewSeeded(d Difficulty, seed uint64) (*Brain, error) {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	switch d {
+	case Easy:
+		return NewBrain(&Random{rng: rng}), nil
+	case Normal, "":
+		return NewBrain(&Team{rng: rng}), nil
+	case Hard:
+		return NewBrain(&Team{rng: rng, Careful: true}), nil
+	}
+	return nil, ErrUnknownDifficulty
+}
+
+func (b *Brain) Observe(view domain.GameView) {
+	b.mem.observe(view)
+}
+
+// Next returns the move the strategy picks for view.
+func (b *Brain) Next(view domain.GameView) (Action, bool) {
+	return b.strategy.Act(view, &b.mem)
+}
+
+// Act implements usecase.BotBrain with the strategy's move, applied as Apply
+// does.
+func (b *Brain) Act(view domain.GameView) (usecase.BotMove, bool) {
+	a, ok := b.Next(view)
+	if !ok {
+		return nil, false
+	}
+	botID := view.You.ID
+	return func(g *domain.Game) error { return Apply(g, botID, a) }, true
+}
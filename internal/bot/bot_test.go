@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"game-server/internal/domain"
+	"game-server/internal/repository/inmem"
+	"game-server/internal/usecase"
+)
+
+func view(role domain.Role, hand ...domain.Card) domain.GameView {
+	return domain.GameView{
+		Status:              domain.GameStatusInGame,
+		Rules:               domain.DefaultRules(),
+		GoalScore:           10,
+		CurrentTurnPlayerID: "me",
+		Players:             []domain.PublicPlayerView{{ID: "me"}, {ID: "x"}, {ID: "y"}},
+		You:                 domain.SelfView{ID: "me", Role: role, Hand: hand},
+	}
+}
+
+var (
+	plus   = domain.Card{Type: domain.CardTypeScore, Score: 1}
+	minus  = domain.Card{Type: domain.CardTypeScore, Score: -2}
+	accuse = domain.Card{Type: domain.CardTypeAccusation}
+)
+
+func TestTeamPlaysForItsRole(t *testing.T) {
+	s := &Team{rng: rand.New(rand.NewPCG(1, 2))}
+	var mem Memory
+	if a, _ := s.Act(view(domain.RoleGood, minus, plus), &mem); a.HandIndex != 1 {
+		t.Fatalf("good played %+v", a)
+	}
+	if a, _ := s.Act(view(domain.RoleImpostor, plus, minus), &mem); a.HandIndex != 1 {
+		t.Fatalf("impostor played %+v", a)
+	}
+}
+
+func TestGoodBotAccusesWhoDrainedTheChest(t *testing.T) {
+	s := &Team{rng: rand.New(rand.NewPCG(1, 2))}
+	var mem Memory
+	v := view(domain.RoleGood, plus, accuse)
+	v.ChestScore, v.CurrentTurnPlayerID = 4, "y"
+	mem.observe(v)
+	v.ChestScore, v.CurrentTurnPlayerID = 2, "me"
+	mem.observe(v)
+
+	a, ok := s.Act(v, &mem)
+	if !ok || a.Type != PlayCard || a.HandIndex != 1 || a.TargetID != "y" {
+		t.Fatalf("action=%+v", a)
+	}
+}
+
+func TestGoodBotCallsOverWhenChestIsFull(t *testing.T) {
+	for _, d := range []Difficulty{Easy, Normal, Hard} {
+		b, err := New(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := view(domain.RoleGood, plus)
+		v.ChestScore, v.CurrentTurnPlayerID = 10, "x"
+		if a, ok := b.Next(v); !ok || a.Type != CallOver {
+			t.Fatalf("%s: action=%+v ok=%v", d, a, ok)
+		}
+	}
+}
+
+func TestUnknownDifficulty(t *testing.T) {
+	if _, err := New("godlike"); err != ErrUnknownDifficulty {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+// TestBotsFinishAGame seats bots next to one human, who plays score cards
+// whenever it is their turn, and waits for the game to end.
+func TestBotsFinishAGame(t *testing.T) {
+	s := usecase.NewLobbyService(inmem.NewLobbyStore())
+	s.SetBotDelay(time.Millisecond)
+	created, err := s.CreateLobby("human")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []Difficulty{Easy, Normal, Hard} {
+		b, _ := New(d)
+		if _, err := s.AddBot(created.LobbyCode, created.PlayerID, "", b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		v, err := s.ViewForPlayer(created.LobbyCode, created.PlayerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Status == domain.GameStatusFinished {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("game stuck: %+v", v)
+		}
+		if v.Status == domain.GameStatusInGame && v.CurrentTurnPlayerID == created.PlayerID {
+			i := 0
+			if j := best(v.You.Hand); j >= 0 {
+				i = j
+			}
+			target := ""
+			if len(livingOthers(v)) > 0 {
+				target = livingOthers(v)[0].ID
+			}
+			if err := s.PlayCard(created.LobbyCode, created.PlayerID, i, play(v, i, target).TargetID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,64 @@
+package bot
+
+import "game-server/internal/domain"
+
+// Memory is what a bot remembers between views of the same game.
+type Memory struct {
+	// Suspicion sums the chest score lost, minus the score gained, on each
+	// player's turns. Only score cards move the chest, so the player whose
+	// turn it was is the one who moved it.
+	Suspicion map[string]int
+
+	lastChest int
+	lastTurn  string
+	inGame    bool
+}
+
+func (m *Memory) observe(v domain.GameView) {
+	if !v.Status.Playing() {
+		if v.Status == domain.GameStatusLobby {
+			*m = Memory{}
+		}
+		m.inGame = false
+		return
+	}
+	if !m.inGame {
+		*m = Memory{Suspicion: make(map[string]int), inGame: true}
+	} else if delta := m.lastChest - v.ChestScore; delta != 0 && m.lastTurn != "" {
+		m.Suspicion[m.lastTurn] += delta
+	}
+	m.lastChest = v.ChestScore
+	m.lastTurn = v.CurrentTurnPlayerID
+}
+
+// mostSuspicious returns the living player, other than self, with the highest
+// positive suspicion of at least min, preferring known impostors.
+func (m *Memory) mostSuspicious(v domain.GameView, min int) string {
+	best, bestScore := "", max(min, 1)-1
+	for _, p := range livingOthers(v) {
+		score := m.Suspicion[p.ID]
+		if v.You.KnownRoles[p.ID] == domain.RoleImpostor {
+			score += 100
+		}
+		if v.You.KnownRoles[p.ID] == domain.RoleGood {
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = p.ID, score
+		}
+	}
+	return best
+}
+
+// leastSuspicious returns the living player, other than self, the table
+// trusts most: a good scapegoat for an impostor.
+func (m *Memory) leastSuspicious(v domain.GameView) string {
+	best, bestScore := "", 0
+	for _, p := range livingOthers(v) {
+		score := m.Suspicion[p.ID] - p.Accusations
+		if best == "" || score < bestScore {
+			best, bestScore = p.ID, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,283 @@
+package bot
+
+import (
+	"math/rand/v2"
+
+	"game-server/internal/domain"
+)
+
+// Random plays a random card at a random target. It still calls over and
+// votes, so tables with easy bots end.
+type Random struct {
+	rng *rand.Rand
+}
+
+func (s *Random) Act(v domain.GameView, mem *Memory) (Action, bool) {
+	if a, ok := outOfTurn(v); ok {
+		if a.Type == Vote {
+			if others := livingOthers(v); len(others) > 0 && s.rng.IntN(2) == 0 {
+				a.TargetID = others[s.rng.IntN(len(others))].ID
+			}
+		}
+		return a, true
+	}
+	if !myTurn(v) {
+		return Action{}, false
+	}
+	i := s.rng.IntN(len(v.You.Hand))
+	return play(v, i, s.randomTarget(v)), true
+}
+
+func (s *Random) randomTarget(v domain.GameView) string {
+	others := livingOthers(v)
+	if len(others) == 0 {
+		return ""
+	}
+	return others[s.rng.IntN(len(others))].ID
+}
+
+// Team plays for its role: good bots feed the chest and accuse whoever has
+// been draining it; impostors drain it and frame trusted players. Careful
+// bots also hide when they draw suspicion and act on thinner evidence.
+type Team struct {
+	rng     *rand.Rand
+	Careful bool
+}
+
+func (s *Team) Act(v domain.GameView, mem *Memory) (Action, bool) {
+	if a, ok := outOfTurn(v); ok {
+		if a.Type == Vote {
+			a.TargetID = s.vote(v, mem)
+		}
+		return a, true
+	}
+	if !myTurn(v) {
+		return Action{}, false
+	}
+	if v.You.Role == domain.RoleImpostor {
+		return s.impostorMove(v, mem), true
+	}
+	return s.goodMove(v, mem), true
+}
+
+func (s *Team) threshold() int {
+	if s.Careful {
+		return 1
+	}
+	return 2
+}
+
+func (s *Team) vote(v domain.GameView, mem *Memory) string {
+	if v.You.Role == domain.RoleImpostor {
+		return mem.leastSuspicious(v)
+	}
+	return mem.mostSuspicious(v, 0)
+}
+
+func (s *Team) goodMove(v domain.GameView, mem *Memory) Action {
+	hand := v.You.Hand
+	me := self(v)
+	if suspect := mem.mostSuspicious(v, s.threshold()); suspect != "" {
+		if i := find(hand, isType(domain.CardTypeAccusation)); i >= 0 {
+			return play(v, i, suspect)
+		}
+		if v.Meeting == nil && me.MeetingsLeft > 0 && mem.Suspicion[suspect] > 2*s.threshold() {
+			return Action{Type: CallMeeting}
+		}
+	}
+	if i := find(hand, isType(domain.CardTypePeek)); i >= 0 {
+		if target := s.unknown(v, mem); target != "" {
+			return play(v, i, target)
+		}
+	}
+	if i := find(hand, isType(domain.CardTypeCleanse)); i >= 0 && me.Accusations > 0 {
+		return play(v, i, "")
+	}
+	if i := best(hand); i >= 0 && hand[i].Score > 0 {
+		return play(v, i, "")
+	}
+	if i := find(hand, isType(domain.CardTypeShield)); i >= 0 && (me.Accusations > 0 || s.Careful) {
+		return play(v, i, "")
+	}
+	if i := best(hand); i >= 0 && hand[i].Score == 0 {
+		return play(v, i, "")
+	}
+	if i := find(hand, isType(domain.CardTypeSwap)); i >= 0 {
+		return play(v, i, s.anyone(v))
+	}
+	if i := find(hand, isType(domain.CardTypeAccusation)); i >= 0 {
+		if suspect := mem.mostSuspicious(v, 0); suspect != "" {
+			return play(v, i, suspect)
+		}
+	}
+	if i := worst(hand); i >= 0 && hand[i].Score < 0 {
+		// Only harmful score cards left: get rid of one without playing it.
+		if v.Rules.AllowDiscard {
+			return Action{Type: Discard, HandIndex: i}
+		}
+		if v.Rules.AllowPass {
+			return Action{Type: Pass}
+		}
+	}
+	return s.leastHarm(v)
+}
+
+func (s *Team) impostorMove(v domain.GameView, mem *Memory) Action {
+	hand := v.You.Hand
+	me := self(v)
+	exposed := s.Careful && me.Accusations >= v.Rules.AccusationsToEliminate-1
+	if exposed {
+		if i := find(hand, isType(domain.CardTypeCleanse)); i >= 0 {
+			return play(v, i, "")
+		}
+		if i := find(hand, isType(domain.CardTypeShield)); i >= 0 {
+			return play(v, i, "")
+		}
+		if i := best(hand); i >= 0 && hand[i].Score >= 0 {
+			return play(v, i, "")
+		}
+	}
+	if i := worst(hand); i >= 0 && hand[i].Score < 0 {
+		return play(v, i, "")
+	}
+	if i := find(hand, isType(domain.CardTypeAccusation)); i >= 0 {
+		if target := mem.leastSuspicious(v); target != "" {
+			return play(v, i, target)
+		}
+	}
+	for _, t := range []domain.CardType{domain.CardTypeSwap, domain.CardTypePeek, domain.CardTypeShield, domain.CardTypeCleanse} {
+		if i := find(hand, isType(t)); i >= 0 {
+			return play(v, i, s.anyone(v))
+		}
+	}
+	if i := worst(hand); i >= 0 && hand[i].Score == 0 {
+		return play(v, i, "")
+	}
+	if v.Rules.AllowPass {
+		return Action{Type: Pass}
+	}
+	return s.leastHarm(v)
+}
+
+// leastHarm plays whatever card is left, preferring the best score card.
+func (s *Team) leastHarm(v domain.GameView) Action {
+	if i := best(v.You.Hand); i >= 0 {
+		return play(v, i, "")
+	}
+	return play(v, 0, s.anyone(v))
+}
+
+// unknown returns a living player whose role the bot has not seen yet.
+func (s *Team) unknown(v domain.GameView, mem *Memory) string {
+	if suspect := mem.mostSuspicious(v, 0); suspect != "" {
+		if _, known := v.You.KnownRoles[suspect]; !known {
+			return suspect
+		}
+	}
+	for _, p := range livingOthers(v) {
+		if _, known := v.You.KnownRoles[p.ID]; !known {
+			return p.ID
+		}
+	}
+	return ""
+}
+
+func (s *Team) anyone(v domain.GameView) string {
+	others := livingOthers(v)
+	if len(others) == 0 {
+		return ""
+	}
+	return others[s.rng.IntN(len(others))].ID
+}
+
+// outOfTurn returns the moves a bot makes regardless of whose turn it is:
+// voting in an open meeting and calling over once the chest is full.
+func outOfTurn(v domain.GameView) (Action, bool) {
+	me := self(v)
+	if me == nil || me.Eliminated {
+		return Action{}, false
+	}
+	switch {
+	case v.Status == domain.GameStatusMeeting && v.Meeting != nil && !contains(v.Meeting.Voted, me.ID):
+		return Action{Type: Vote}, true
+	case v.Status == domain.GameStatusInGame && v.You.Role == domain.RoleGood && v.ChestScore >= v.GoalScore:
+		return Action{Type: CallOver}, true
+	}
+	return Action{}, false
+}
+
+func myTurn(v domain.GameView) bool {
+	return v.Status == domain.GameStatusInGame && v.CurrentTurnPlayerID == v.You.ID && len(v.You.Hand) > 0
+}
+
+// play plays card i, passing target only if the card takes one.
+func play(v domain.GameView, i int, target string) Action {
+	a := Action{Type: PlayCard, HandIndex: i}
+	if mode, _ := domain.CardTarget(v.You.Hand[i].Type); mode == domain.TargetOther {
+		a.TargetID = target
+	}
+	return a
+}
+
+func self(v domain.GameView) *domain.PublicPlayerView {
+	for i := range v.Players {
+		if v.Players[i].ID == v.You.ID {
+			return &v.Players[i]
+		}
+	}
+	return nil
+}
+
+func livingOthers(v domain.GameView) []domain.PublicPlayerView {
+	var out []domain.PublicPlayerView
+	for _, p := range v.Players {
+		if !p.Eliminated && p.ID != v.You.ID {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func isType(t domain.CardType) func(domain.Card) bool {
+	return func(c domain.Card) bool { return c.Type == t }
+}
+
+func find(hand []domain.Card, match func(domain.Card) bool) int {
+	for i, c := range hand {
+		if match(c) {
+			return i
+		}
+	}
+	return -1
+}
+
+// best returns the index of the highest score card, or -1.
+func best(hand []domain.Card) int {
+	idx := -1
+	for i, c := range hand {
+		if c.IsScore() && (idx < 0 || c.Score > hand[idx].Score) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// worst returns the index of the lowest score card, or -1.
+func worst(hand []domain.Card) int {
+	idx := -1
+	for i, c := range hand {
+		if c.IsScore() && (idx < 0 || c.Score < hand[idx].Score) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+func contains(ids []string, id string) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
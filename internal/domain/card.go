@@ -1,17 +1,22 @@
 package domain
 
-// CardType distinguishes playable cards.
+// CardType distinguishes playable cards. Every type has a CardEffect
+// registered in effects.go.
 type CardType string
 
 const (
 	CardTypeScore      CardType = "score"
 	CardTypeAccusation CardType = "accusation"
+	CardTypePeek       CardType = "peek"    // privately learn a player's role
+	CardTypeShield     CardType = "shield"  // block the next accusation against yourself
+	CardTypeSwap       CardType = "swap"    // trade a random card with a player
+	CardTypeCleanse    CardType = "cleanse" // remove one accusation from a player, yourself by default
 )
 
 // Card is a single card in the deck/hand.
 //
 // For CardTypeScore, Score is one of: +1, 0, -2.
-// For CardTypeAccusation, Score is always 0.
+// For every other type, Score is always 0.
 type Card struct {
 	Type  CardType `json:"type"`
 	Score int      `json:"score"`
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DeckEntry describes how many copies of one card go into the deck.
+// The number of copies is PerPlayer*players + Fixed.
+type DeckEntry struct {
+	Type      CardType `json:"type" yaml:"type"`
+	Score     int      `json:"score,omitempty" yaml:"score,omitempty"`
+	PerPlayer int      `json:"perPlayer,omitempty" yaml:"perPlayer,omitempty"`
+	Fixed     int      `json:"fixed,omitempty" yaml:"fixed,omitempty"`
+}
+
+// DeckSpec is a data-driven deck composition.
+type DeckSpec struct {
+	Name  string      `json:"name" yaml:"name"`
+	Cards []DeckEntry `json:"cards" yaml:"cards"`
+}
+
+// ClassicDeck returns the default deck: per player 6x(+1), 4x(0), 3x(-2), 3x(accusation).
+func ClassicDeck() DeckSpec {
+	return DeckSpec{
+		Name: "classic",
+		Cards: []DeckEntry{
+			{Type: CardTypeScore, Score: 1, PerPlayer: 6},
+			{Type: CardTypeScore, Score: 0, PerPlayer: 4},
+			{Type: CardTypeScore, Score: -2, PerPlayer: 3},
+			{Type: CardTypeAccusation, PerPlayer: 3},
+		},
+	}
+}
+
+// TricksDeck is the classic deck with a handful of action cards mixed in.
+func TricksDeck() DeckSpec {
+	return DeckSpec{
+		Name: "tricks",
+		Cards: []DeckEntry{
+			{Type: CardTypeScore, Score: 1, PerPlayer: 6},
+			{Type: CardTypeScore, Score: 0, PerPlayer: 3},
+			{Type: CardTypeScore, Score: -2, PerPlayer: 3},
+			{Type: CardTypeAccusation, PerPlayer: 3},
+			{Type: CardTypePeek, Fixed: 2},
+			{Type: CardTypeShield, Fixed: 2},
+			{Type: CardTypeSwap, Fixed: 2},
+			{Type: CardTypeCleanse, Fixed: 2},
+		},
+	}
+}
+
+// ParseDeckSpec decodes and validates a JSON deck spec.
+func ParseDeckSpec(data []byte) (DeckSpec, error) {
+	var d DeckSpec
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&d); err != nil {
+		return DeckSpec{}, fmt.Errorf("%w: %v", ErrInvalidDeck, err)
+	}
+	if err := d.Validate(); err != nil {
+		return DeckSpec{}, err
+	}
+	return d, nil
+}
+
+// ParseDeckSpecYAML decodes and validates a YAML deck spec. It uses the same
+// field names as the JSON form.
+func ParseDeckSpecYAML(data []byte) (DeckSpec, error) {
+	var d DeckSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&d); err != nil && !errors.Is(err, io.EOF) {
+		return DeckSpec{}, fmt.Errorf("%w: %v", ErrInvalidDeck, err)
+	}
+	if err := d.Validate(); err != nil {
+		return DeckSpec{}, err
+	}
+	return d, nil
+}
+
+// Validate checks the spec's structure independently of player count.
+func (d DeckSpec) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidDeck)
+	}
+	if len(d.Cards) == 0 {
+		return fmt.Errorf("%w: no cards", ErrInvalidDeck)
+	}
+	for i, e := range d.Cards {
+		if _, ok := cardEffect(e.Type); !ok {
+			return fmt.Errorf("%w: entry %d: unknown card type %q", ErrInvalidDeck, i, e.Type)
+		}
+		if e.Type != CardTypeScore && e.Score != 0 {
+			return fmt.Errorf("%w: entry %d: %s cards have no score", ErrInvalidDeck, i, e.Type)
+		}
+		if e.PerPlayer < 0 || e.Fixed < 0 {
+			return fmt.Errorf("%w: entry %d: negative count", ErrInvalidDeck, i)
+		}
+	}
+	return nil
+}
+
+// Size returns the number of cards built for the given player count.
+func (d DeckSpec) Size(players int) int {
+	n := 0
+	for _, e := range d.Cards {
+		n += e.PerPlayer*players + e.Fixed
+	}
+	return n
+}
+
+// ValidateFor checks that the deck can deal every player a starting hand.
+func (d DeckSpec) ValidateFor(players int, rules GameRules) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if need := rules.StartingHandSize * players; d.Size(players) < need {
+		return fmt.Errorf("%w: %d cards for %d players, need at least %d", ErrDeckTooSmall, d.Size(players), players, need)
+	}
+	return nil
+}
+
+// Build returns the unshuffled deck for the given player count.
+func (d DeckSpec) Build(players int) []Card {
+	deck := make([]Card, 0, d.Size(players))
+	for _, e := range d.Cards {
+		for j := 0; j < e.PerPlayer*players+e.Fixed; j++ {
+			deck = append(deck, Card{Type: e.Type, Score: e.Score})
+		}
+	}
+	return deck
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassicDeckSize(t *testing.T) {
+	if got := len(ClassicDeck().Build(5)); got != 80 {
+		t.Fatalf("size=%d", got)
+	}
+}
+
+func TestParseDeckSpec(t *testing.T) {
+	raw := `{"name":"short","cards":[
+		{"type":"score","score":1,"perPlayer":4},
+		{"type":"score","score":-2,"fixed":5},
+		{"type":"accusation","perPlayer":2}
+	]}`
+	d, err := ParseDeckSpec([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Size(3); got != 4*3+5+2*3 {
+		t.Fatalf("size=%d", got)
+	}
+	if _, err := ParseDeckSpec([]byte(`{"name":"x","cards":[{"type":"joker","fixed":1}]}`)); !errors.Is(err, ErrInvalidDeck) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestParseDeckSpecYAML(t *testing.T) {
+	raw := `
+name: short
+cards:
+  - {type: score, score: 1, perPlayer: 4}
+  - type: score
+    score: -2
+    fixed: 5
+  - {type: accusation, perPlayer: 2}
+`
+	d, err := ParseDeckSpecYAML([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Size(3); got != 4*3+5+2*3 {
+		t.Fatalf("size=%d", got)
+	}
+	for _, bad := range []string{"name: x\ncards: [{type: score, copies: 3}]", "name: x", ""} {
+		if _, err := ParseDeckSpecYAML([]byte(bad)); !errors.Is(err, ErrInvalidDeck) {
+			t.Fatalf("%q: err=%v", bad, err)
+		}
+	}
+}
+
+func TestStartRejectsSmallDeck(t *testing.T) {
+	g := NewLobbyGame()
+	g.Players = []*Player{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	if err := g.SetDeck(DeckSpec{Name: "tiny", Cards: []DeckEntry{{Type: CardTypeScore, Score: 1, Fixed: 8}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); !errors.Is(err, ErrDeckTooSmall) {
+		t.Fatalf("err=%v", err)
+	}
+	if g.Status != GameStatusLobby {
+		t.Fatalf("status=%s", g.Status)
+	}
+}
+
+func TestDeckSpecAcceptsRegisteredEffects(t *testing.T) {
+	if err := TricksDeck().Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bad := DeckSpec{Name: "x", Cards: []DeckEntry{{Type: CardTypePeek, Score: 1, Fixed: 1}}}
+	if err := bad.Validate(); !errors.Is(err, ErrInvalidDeck) {
+		t.Fatalf("err=%v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+// DiscardCard spends the turn putting a card face-down on the discard pile
+// and drawing a replacement. It requires Rules.AllowDiscard.
+func (g *Game) DiscardCard(playerID string, handIndex int) error {
+	p, err := g.requireTurn(playerID)
+	if err != nil {
+		return err
+	}
+	if !g.Rules.AllowDiscard {
+		return ErrActionDisabled
+	}
+	card, err := removeCard(&p.Hand, handIndex)
+	if err != nil {
+		return err
+	}
+	g.DiscardPile = append(g.DiscardPile, card)
+
+	e := Event{Type: EventDiscarded, PlayerID: playerID, HandIndex: handIndex, Card: &card}
+	g.afterPlayDrawAdvance(p, &e)
+	err = g.checkEndConditions()
+	g.record(e)
+	return err
+}
+
+// PassTurn ends the turn without playing or drawing. It requires Rules.AllowPass,
+// and is refused once the draw pile is played out so the game cannot stall.
+func (g *Game) PassTurn(playerID string) error {
+	if _, err := g.requireTurn(playerID); err != nil {
+		return err
+	}
+	if !g.Rules.AllowPass || g.playingOut() {
+		return ErrActionDisabled
+	}
+	g.advanceTurn()
+	g.record(Event{Type: EventPassed, PlayerID: playerID})
+	return nil
+}
+
+// requireTurn checks that playerID may act now and returns them.
+func (g *Game) requireTurn(playerID string) (*Player, error) {
+	if err := g.requireInGame(); err != nil {
+		return nil, err
+	}
+	p, err := g.mustPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Eliminated {
+		return nil, ErrPlayerEliminated
+	}
+	if g.CurrentPlayerID() != playerID {
+		return nil, ErrNotPlayersTurn
+	}
+	return p, nil
+}
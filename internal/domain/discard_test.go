@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func startWithRules(t *testing.T, r GameRules) *Game {
+	t.Helper()
+	g := NewLobbyGame()
+	if err := g.SetRules(r); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := g.AddPlayer(&Player{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.SetSeed(13); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestDiscardAndPassDisabledByDefault(t *testing.T) {
+	g := startSeeded(t, 1)
+	id := g.CurrentPlayerID()
+	if err := g.DiscardCard(id, 0); !errors.Is(err, ErrActionDisabled) {
+		t.Fatalf("discard: %v", err)
+	}
+	if err := g.PassTurn(id); !errors.Is(err, ErrActionDisabled) {
+		t.Fatalf("pass: %v", err)
+	}
+}
+
+func TestDiscardDrawsReplacementAndEndsTurn(t *testing.T) {
+	r := DefaultRules()
+	r.AllowDiscard = true
+	g := startWithRules(t, r)
+	id := g.CurrentPlayerID()
+	p, _ := g.mustPlayer(id)
+	discarded := p.Hand[1]
+	draws := len(g.DrawPile)
+
+	if err := g.DiscardCard(id, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Hand) != r.StartingHandSize || len(g.DrawPile) != draws-1 || g.ChestScore != 0 {
+		t.Fatalf("hand=%d draw=%d chest=%d", len(p.Hand), len(g.DrawPile), g.ChestScore)
+	}
+	if g.DiscardPile[len(g.DiscardPile)-1] != discarded || g.CurrentPlayerID() == id {
+		t.Fatalf("discard pile or turn not updated")
+	}
+	if e := g.Events[len(g.Events)-1]; e.Type != EventDiscarded || *e.Card != discarded {
+		t.Fatalf("event=%+v", e)
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPassKeepsHand(t *testing.T) {
+	r := DefaultRules()
+	r.AllowPass = true
+	g := startWithRules(t, r)
+	id := g.CurrentPlayerID()
+	p, _ := g.mustPlayer(id)
+	hand := append([]Card(nil), p.Hand...)
+
+	if err := g.PassTurn(id); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Hand) != len(hand) || g.CurrentPlayerID() == id {
+		t.Fatalf("hand=%v turn=%s", p.Hand, g.CurrentPlayerID())
+	}
+	if err := g.PassTurn(id); !errors.Is(err, ErrNotPlayersTurn) {
+		t.Fatalf("err=%v", err)
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,133 @@
+package domain
+
+import "sync"
+
+// TargetMode is what a card accepts as its target.
+type TargetMode int
+
+const (
+	TargetNone     TargetMode = iota // no target allowed
+	TargetOther                      // another living player, required
+	TargetOptional                   // any living player; the player themself when omitted
+)
+
+// CardEffect resolves a played card.
+//
+// Game.PlayCard validates the turn, the hand index and the target against
+// Target before calling Resolve, so Resolve cannot fail. The played card is
+// already out of the player's hand and goes to the discard pile afterwards.
+// Resolve must draw randomness from the game's source only, so replays match.
+type CardEffect interface {
+	Target() TargetMode
+	// Resolve applies the card and notes anything worth replaying on e.
+	// target is nil for TargetNone cards.
+	Resolve(g *Game, player, target *Player, card Card, e *Event)
+}
+
+var (
+	cardEffectsMu sync.RWMutex
+	cardEffects   = map[CardType]CardEffect{
+		CardTypeScore:      scoreEffect{},
+		CardTypeAccusation: accusationEffect{},
+		CardTypePeek:       peekEffect{},
+		CardTypeShield:     shieldEffect{},
+		CardTypeSwap:       swapEffect{},
+		CardTypeCleanse:    cleanseEffect{},
+	}
+)
+
+// RegisterCardEffect makes a new card type playable and valid in deck specs.
+// It is safe to call while games are running; games already started keep
+// their deck but resolve the type with the new effect.
+func RegisterCardEffect(t CardType, effect CardEffect) {
+	cardEffectsMu.Lock()
+	defer cardEffectsMu.Unlock()
+	cardEffects[t] = effect
+}
+
+func cardEffect(t CardType) (CardEffect, bool) {
+	cardEffectsMu.RLock()
+	defer cardEffectsMu.RUnlock()
+	effect, ok := cardEffects[t]
+	return effect, ok
+}
+
+// CardTarget reports what a card type accepts as its target, and whether the
+// type is playable at all.
+func CardTarget(t CardType) (TargetMode, bool) {
+	effect, ok := cardEffect(t)
+	if !ok {
+		return TargetNone, false
+	}
+	return effect.Target(), true
+}
+
+type scoreEffect struct{}
+
+func (scoreEffect) Target() TargetMode { return TargetNone }
+
+func (scoreEffect) Resolve(g *Game, _, _ *Player, card Card, _ *Event) {
+	g.ChestScore += card.Score
+}
+
+type accusationEffect struct{}
+
+func (accusationEffect) Target() TargetMode { return TargetOther }
+
+func (accusationEffect) Resolve(g *Game, _, target *Player, _ Card, e *Event) {
+	if target.Shields > 0 {
+		target.Shields--
+		e.Blocked = true
+		return
+	}
+	target.Accusations++
+	if target.Accusations >= g.Rules.AccusationsToEliminate {
+		target.Eliminated = true
+		e.Eliminated = []string{target.ID}
+	}
+}
+
+type peekEffect struct{}
+
+func (peekEffect) Target() TargetMode { return TargetOther }
+
+func (peekEffect) Resolve(_ *Game, player, target *Player, _ Card, _ *Event) {
+	if player.KnownRoles == nil {
+		player.KnownRoles = make(map[string]Role)
+	}
+	player.KnownRoles[target.ID] = target.Role
+}
+
+type shieldEffect struct{}
+
+func (shieldEffect) Target() TargetMode { return TargetNone }
+
+func (shieldEffect) Resolve(_ *Game, player, _ *Player, _ Card, _ *Event) {
+	player.Shields++
+}
+
+type swapEffect struct{}
+
+func (swapEffect) Target() TargetMode { return TargetOther }
+
+// Resolve trades a random card of each hand; it does nothing if either hand is empty.
+func (swapEffect) Resolve(g *Game, player, target *Player, _ Card, e *Event) {
+	if len(player.Hand) == 0 || len(target.Hand) == 0 {
+		return
+	}
+	i := randInt(g.rng, 0, len(player.Hand))
+	j := randInt(g.rng, 0, len(target.Hand))
+	given, taken := player.Hand[i], target.Hand[j]
+	player.Hand[i], target.Hand[j] = taken, given
+	e.Given, e.Taken = &given, &taken
+}
+
+type cleanseEffect struct{}
+
+func (cleanseEffect) Target() TargetMode { return TargetOptional }
+
+func (cleanseEffect) Resolve(_ *Game, _, target *Player, _ Card, _ *Event) {
+	if target.Accusations > 0 {
+		target.Accusations--
+	}
+}
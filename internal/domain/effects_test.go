@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// giveCurrent replaces the current player's hand and returns them with the
+// next living player.
+func giveCurrent(t *testing.T, g *Game, hand ...Card) (*Player, *Player) {
+	t.Helper()
+	p, _ := g.mustPlayer(g.CurrentPlayerID())
+	p.Hand = hand
+	other, _ := g.mustPlayer(firstOtherActive(g, p.ID))
+	return p, other
+}
+
+func TestShieldBlocksNextAccusation(t *testing.T) {
+	g := startSeeded(t, 4)
+	p, other := giveCurrent(t, g, Card{Type: CardTypeShield})
+	if err := g.PlayCard(p.ID, 0, other.ID); !errors.Is(err, ErrTargetInvalid) {
+		t.Fatalf("shield with target: %v", err)
+	}
+	if err := g.PlayCard(p.ID, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if p.Shields != 1 {
+		t.Fatalf("shields=%d", p.Shields)
+	}
+
+	accuser, _ := g.mustPlayer(g.CurrentPlayerID())
+	accuser.Hand = []Card{{Type: CardTypeAccusation}}
+	if err := g.PlayCard(accuser.ID, 0, p.ID); err != nil {
+		t.Fatal(err)
+	}
+	last := g.Events[len(g.Events)-1]
+	if p.Accusations != 0 || p.Shields != 0 || !last.Blocked {
+		t.Fatalf("accusations=%d shields=%d event=%+v", p.Accusations, p.Shields, last)
+	}
+}
+
+func TestPeekRevealsRoleToPlayerOnly(t *testing.T) {
+	g := startSeeded(t, 6)
+	p, other := giveCurrent(t, g, Card{Type: CardTypePeek})
+	if err := g.PlayCard(p.ID, 0, ""); !errors.Is(err, ErrTargetInvalid) {
+		t.Fatalf("peek without target: %v", err)
+	}
+	if err := g.PlayCard(p.ID, 0, other.ID); err != nil {
+		t.Fatal(err)
+	}
+	view, _ := g.ViewFor(p.ID, "X")
+	if view.You.KnownRoles[other.ID] != other.Role {
+		t.Fatalf("known=%v", view.You.KnownRoles)
+	}
+	view, _ = g.ViewFor(other.ID, "X")
+	if len(view.You.KnownRoles) != 0 {
+		t.Fatalf("peek leaked: %v", view.You.KnownRoles)
+	}
+}
+
+func TestSwapTradesCards(t *testing.T) {
+	g := startSeeded(t, 9)
+	keep := Card{Type: CardTypeScore, Score: -2}
+	p, other := giveCurrent(t, g, Card{Type: CardTypeSwap}, keep)
+	other.Hand = []Card{{Type: CardTypeAccusation}}
+	if err := g.PlayCard(p.ID, 0, other.ID); err != nil {
+		t.Fatal(err)
+	}
+	if other.Hand[0] != keep || p.Hand[0].Type != CardTypeAccusation {
+		t.Fatalf("hand=%v other=%v", p.Hand, other.Hand)
+	}
+}
+
+func TestCleanseDefaultsToSelf(t *testing.T) {
+	g := startSeeded(t, 2)
+	p, _ := giveCurrent(t, g, Card{Type: CardTypeCleanse})
+	p.Accusations = 2
+	if err := g.PlayCard(p.ID, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if p.Accusations != 1 {
+		t.Fatalf("accusations=%d", p.Accusations)
+	}
+}
+
+func TestPlayScoreCardRejectsActionCards(t *testing.T) {
+	g := startSeeded(t, 3)
+	p, _ := giveCurrent(t, g, Card{Type: CardTypeShield})
+	if err := g.PlayScoreCard(p.ID, 0); !errors.Is(err, ErrInvalidCardType) {
+		t.Fatalf("err=%v", err)
+	}
+	if len(p.Hand) != 1 {
+		t.Fatalf("card lost on rejected play")
+	}
+}
+
+func TestTricksDeckReplays(t *testing.T) {
+	g := NewLobbyGame()
+	if err := g.SetDeck(TricksDeck()); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		if err := g.AddPlayer(&Player{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.SetSeed(17); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; g.Status == GameStatusInGame; i++ {
+		if i > 1000 {
+			t.Fatalf("game did not end")
+		}
+		id := g.CurrentPlayerID()
+		p, _ := g.mustPlayer(id)
+		target := ""
+		if mode, _ := CardTarget(p.Hand[0].Type); mode == TargetOther {
+			target = firstOtherActive(g, id)
+		}
+		if err := g.PlayCard(id, 0, target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterCardEffectWhileGamesRun(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			RegisterCardEffect("test_noop", shieldEffect{})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, _ = CardTarget(CardTypeScore)
+	}
+	<-done
+	if _, ok := CardTarget("test_noop"); !ok {
+		t.Fatalf("registered type is not playable")
+	}
+}
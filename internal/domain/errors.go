@@ -19,4 +19,18 @@ var (
 	ErrAlreadyInGame     = errors.New("game already started")
 	ErrDeckEmpty         = errors.New("draw pile is empty")
 	ErrDuplicatePlayerID = errors.New("duplicate player id")
+	ErrInvalidRules      = errors.New("invalid game rules")
+	ErrInvalidDeck       = errors.New("invalid deck spec")
+	ErrDeckTooSmall      = errors.New("deck too small")
+	ErrReplayMismatch    = errors.New("replay does not match recorded game")
+	ErrGameNotFinished   = errors.New("game not finished")
+	ErrTurnOutOfRange    = errors.New("turn out of range")
+	ErrInvalidMatch      = errors.New("invalid match config")
+	ErrMatchFinished     = errors.New("match already finished")
+	ErrNotImpostor       = errors.New("only impostors can use the team channel")
+	ErrMeetingInProgress = errors.New("meeting in progress")
+	ErrNoMeeting         = errors.New("no meeting in progress")
+	ErrNoMeetingsLeft    = errors.New("no meetings left")
+	ErrAlreadyVoted      = errors.New("already voted")
+	ErrActionDisabled    = errors.New("action disabled by the rules")
 )
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EventType identifies a recorded game action.
+type EventType string
+
+const (
+	EventGameStarted EventType = "game_started"
+	EventCardPlayed  EventType = "card_played"
+	EventOverCalled  EventType = "over_called"
+	EventTurnTimeout EventType = "turn_timeout"
+	EventPlayerLeft  EventType = "player_left"
+	EventTeamChat    EventType = "team_chat"
+	EventDiscarded   EventType = "card_discarded"
+	EventPassed      EventType = "turn_passed"
+
+	EventMeetingCalled EventType = "meeting_called"
+	EventVoteCast      EventType = "vote_cast"
+	EventMeetingClosed EventType = "meeting_closed"
+)
+
+// EventPlayer is a seat as dealt at game start.
+type EventPlayer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// Event is one entry of the game's ordered action log.
+//
+// Together with Game.Seed, the log is enough to rebuild the game (see Replay).
+// It contains private information and must not be sent to players mid-game.
+type Event struct {
+	Seq      int       `json:"seq"`
+	Type     EventType `json:"type"`
+	PlayerID string    `json:"playerId,omitempty"`
+
+	// game_started
+	Rules   *GameRules    `json:"rules,omitempty"`
+	Deck    *DeckSpec     `json:"deck,omitempty"`
+	Players []EventPlayer `json:"players,omitempty"`
+
+	// card_played, card_discarded, turn_timeout, vote_cast, meeting_closed
+	HandIndex  int      `json:"handIndex"`
+	Card       *Card    `json:"card,omitempty"`
+	TargetID   string   `json:"targetId,omitempty"`
+	Eliminated []string `json:"eliminated,omitempty"`
+	Drawn      *Card    `json:"drawn,omitempty"`
+	DeckEmpty  bool     `json:"deckEmpty,omitempty"`
+	Reshuffled bool     `json:"reshuffled,omitempty"` // the discard pile was reshuffled before drawing
+	Blocked    bool     `json:"blocked,omitempty"`    // accusation stopped by a shield
+	Given      *Card    `json:"given,omitempty"`      // swap: card handed to the target
+	Taken      *Card    `json:"taken,omitempty"`      // swap: card taken from the target
+
+	// team_chat
+	Text string `json:"text,omitempty"`
+
+	// meeting_closed: final ballots, voter ID to target ID ("" skips).
+	Votes map[string]string `json:"votes,omitempty"`
+
+	// Resulting state after the action.
+	ChestScore int        `json:"chestScore"`
+	Status     GameStatus `json:"status"`
+	Winner     Winner     `json:"winner"`
+}
+
+// record stamps the resulting state on e and appends it to the log.
+func (g *Game) record(e Event) {
+	e.Seq = len(g.Events)
+	e.ChestScore = g.ChestScore
+	e.Status = g.Status
+	e.Winner = g.Winner
+	g.Events = append(g.Events, e)
+}
+
+// Replay rebuilds a game from its seed and event log, re-applying every action
+// and checking that each one produces the recorded event.
+func Replay(seed uint64, events []Event) (*Game, error) {
+	return replayN(seed, events, len(events))
+}
+
+// replayN replays the first n events.
+func replayN(seed uint64, events []Event, n int) (*Game, error) {
+	return replayEach(seed, events, n, nil)
+}
+
+// replayEach replays the first n events, calling after (if set) with the
+// state after each of them, the start event included.
+func replayEach(seed uint64, events []Event, n int, after func(*Game)) (*Game, error) {
+	if len(events) == 0 || events[0].Type != EventGameStarted {
+		return nil, fmt.Errorf("%w: log must begin with %s", ErrReplayMismatch, EventGameStarted)
+	}
+	start := events[0]
+	if start.Rules == nil || start.Deck == nil {
+		return nil, fmt.Errorf("%w: start event lacks rules or deck", ErrReplayMismatch)
+	}
+	g := NewLobbyGame()
+	if err := g.SetRules(*start.Rules); err != nil {
+		return nil, err
+	}
+	if err := g.SetDeck(*start.Deck); err != nil {
+		return nil, err
+	}
+	for _, p := range start.Players {
+		if err := g.AddPlayer(&Player{ID: p.ID, Name: p.Name}); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.SetSeed(seed); err != nil {
+		return nil, err
+	}
+	if err := g.Start(); err != nil {
+		return nil, err
+	}
+	if err := checkReplayed(g, events[0]); err != nil {
+		return nil, err
+	}
+	if after != nil {
+		after(g)
+	}
+
+	for _, e := range events[1:n] {
+		if err := g.apply(e); err != nil {
+			return nil, fmt.Errorf("%w: event %d: %v", ErrReplayMismatch, e.Seq, err)
+		}
+		if err := checkReplayed(g, e); err != nil {
+			return nil, err
+		}
+		if after != nil {
+			after(g)
+		}
+	}
+	return g, nil
+}
+
+// apply re-executes a recorded action.
+func (g *Game) apply(e Event) error {
+	switch e.Type {
+	case EventCardPlayed:
+		return g.PlayCard(e.PlayerID, e.HandIndex, e.TargetID)
+	case EventDiscarded:
+		return g.DiscardCard(e.PlayerID, e.HandIndex)
+	case EventPassed:
+		return g.PassTurn(e.PlayerID)
+	case EventOverCalled:
+		return g.CallOver(e.PlayerID)
+	case EventTurnTimeout:
+		return g.HandleTurnTimeout(e.PlayerID)
+	case EventPlayerLeft:
+		return g.RemovePlayer(e.PlayerID)
+	case EventTeamChat:
+		_, err := g.TeamChat(e.PlayerID, e.Text)
+		return err
+	case EventMeetingCalled:
+		return g.CallMeeting(e.PlayerID)
+	case EventVoteCast:
+		return g.CastVote(e.PlayerID, e.TargetID)
+	case EventMeetingClosed:
+		return g.CloseMeeting()
+	default:
+		return fmt.Errorf("unexpected event type %q", e.Type)
+	}
+}
+
+func checkReplayed(g *Game, want Event) error {
+	if len(g.Events) == 0 {
+		return fmt.Errorf("%w: event %d was not recorded", ErrReplayMismatch, want.Seq)
+	}
+	if got := g.Events[len(g.Events)-1]; !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("%w: event %d: got %+v, want %+v", ErrReplayMismatch, want.Seq, got, want)
+	}
+	return nil
+}
+
+// VerifyReplay replays g from its seed and event log and checks that the
+// result is identical to g's current state.
+func VerifyReplay(g *Game) error {
+	r, err := Replay(g.Seed, g.Events)
+	if err != nil {
+		return err
+	}
+	switch {
+	case r.Status != g.Status, r.Winner != g.Winner:
+		return fmt.Errorf("%w: outcome differs", ErrReplayMismatch)
+	case r.ChestScore != g.ChestScore, r.GoalScore != g.GoalScore:
+		return fmt.Errorf("%w: scores differ", ErrReplayMismatch)
+	case r.TurnIndex != g.TurnIndex, r.Turn != g.Turn, r.Reshuffles != g.Reshuffles:
+		return fmt.Errorf("%w: turn differs", ErrReplayMismatch)
+	case !reflect.DeepEqual(r.DrawPile, g.DrawPile), !reflect.DeepEqual(r.DiscardPile, g.DiscardPile):
+		return fmt.Errorf("%w: piles differ", ErrReplayMismatch)
+	case len(r.Players) != len(g.Players):
+		return fmt.Errorf("%w: player count differs", ErrReplayMismatch)
+	}
+	for i := range g.Players {
+		if !reflect.DeepEqual(*r.Players[i], *g.Players[i]) {
+			return fmt.Errorf("%w: player %s differs", ErrReplayMismatch, g.Players[i].ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// playOut plays the first card of each hand until the game ends.
+func playOut(t *testing.T, g *Game) {
+	t.Helper()
+	for i := 0; g.Status == GameStatusInGame; i++ {
+		if i > 1000 {
+			t.Fatalf("game did not end")
+		}
+		id := g.CurrentPlayerID()
+		p, _ := g.mustPlayer(id)
+		target := firstOtherActive(g, id)
+		card := 0
+		for card < len(p.Hand) && target == "" && p.Hand[card].IsAccusation() {
+			card++
+		}
+		var err error
+		switch {
+		case card == len(p.Hand):
+			// Last one standing with only accusations left.
+			err = g.HandleTurnTimeout(id)
+		case p.Hand[card].IsAccusation():
+			err = g.PlayAccusationCard(id, card, target)
+		default:
+			err = g.PlayScoreCard(id, card)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func firstOtherActive(g *Game, id string) string {
+	for _, p := range g.Players {
+		if p.Active() && p.ID != id {
+			return p.ID
+		}
+	}
+	return ""
+}
+
+func TestEventsRecorded(t *testing.T) {
+	g := startSeeded(t, 7)
+	if len(g.Events) != 1 || g.Events[0].Type != EventGameStarted {
+		t.Fatalf("events=%+v", g.Events)
+	}
+	id := g.CurrentPlayerID()
+	p, _ := g.mustPlayer(id)
+	p.Hand[0] = Card{Type: CardTypeScore, Score: 1}
+	if err := g.PlayScoreCard(id, 0); err != nil {
+		t.Fatal(err)
+	}
+	e := g.Events[1]
+	if e.Type != EventCardPlayed || e.PlayerID != id || e.ChestScore != 1 || e.Drawn == nil {
+		t.Fatalf("event=%+v", e)
+	}
+}
+
+func TestReplayReachesIdenticalState(t *testing.T) {
+	g := startSeeded(t, 1234)
+	playOut(t, g)
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayDetectsTampering(t *testing.T) {
+	g := startSeeded(t, 99)
+	playOut(t, g)
+	events := append([]Event(nil), g.Events...)
+	events[1].ChestScore += 5
+	if _, err := Replay(g.Seed, events); !errors.Is(err, ErrReplayMismatch) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestTeamChatOnlyForImpostorsAndReplayed(t *testing.T) {
+	g := startSeeded(t, 77)
+	var impostor, good string
+	for _, p := range g.Players {
+		if p.Role == RoleImpostor && impostor == "" {
+			impostor = p.ID
+		}
+		if p.Role == RoleGood && good == "" {
+			good = p.ID
+		}
+	}
+	if _, err := g.TeamChat(good, "hi"); err != ErrNotImpostor {
+		t.Fatalf("err=%v", err)
+	}
+	recipients, err := g.TeamChat(impostor, "play the -2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range recipients {
+		p, _ := g.mustPlayer(id)
+		if p.Role != RoleImpostor {
+			t.Fatalf("good player %s would read team chat", id)
+		}
+	}
+	playOut(t, g)
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := g.ReplayDocument("X")
+	if doc.Events[1].Type != EventTeamChat || doc.Events[1].Text != "play the -2" {
+		t.Fatalf("team chat missing from replay: %+v", doc.Events[1])
+	}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// startBlankDeck starts four players on a deck of 16 zero-score cards, so the
+// draw pile holds 4 cards after dealing.
+func startBlankDeck(t *testing.T, policy DeckExhaustion, maxReshuffles int) *Game {
+	t.Helper()
+	r := DefaultRules()
+	r.DeckExhaustion = policy
+	r.MaxReshuffles = maxReshuffles
+	return startBlankDeckWithRules(t, r)
+}
+
+func startBlankDeckWithRules(t *testing.T, r GameRules) *Game {
+	t.Helper()
+	g := NewLobbyGame()
+	if err := g.SetDeck(DeckSpec{Name: "blank", Cards: []DeckEntry{{Type: CardTypeScore, Fixed: 16}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SetRules(r); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := g.AddPlayer(&Player{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.SetSeed(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// playAll plays the first card of the current player until the game ends and
+// returns the number of plays.
+func playAll(t *testing.T, g *Game) int {
+	t.Helper()
+	n := 0
+	for ; g.Status == GameStatusInGame; n++ {
+		if n > 1000 {
+			t.Fatalf("game did not end")
+		}
+		if err := g.PlayScoreCard(g.CurrentPlayerID(), 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return n
+}
+
+func TestDeckExhaustionEndsGameByDefault(t *testing.T) {
+	g := startBlankDeck(t, DeckExhaustionEnd, 0)
+	if n := playAll(t, g); n != 5 {
+		t.Fatalf("plays=%d", n)
+	}
+}
+
+func TestDeckExhaustionReshufflesUpToLimit(t *testing.T) {
+	g := startBlankDeck(t, DeckExhaustionReshuffle, 2)
+	playAll(t, g)
+	if g.Reshuffles != 2 {
+		t.Fatalf("reshuffles=%d", g.Reshuffles)
+	}
+	if v := g.SpectatorView("X"); v.Reshuffles != 2 {
+		t.Fatalf("view reshuffles=%d", v.Reshuffles)
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeckExhaustionPlayOutEmptiesHands(t *testing.T) {
+	g := startBlankDeck(t, DeckExhaustionPlayOut, 0)
+	if n := playAll(t, g); n != 16 {
+		t.Fatalf("plays=%d", n)
+	}
+	for _, p := range g.Players {
+		if len(p.Hand) != 0 {
+			t.Fatalf("%s still holds %v", p.ID, p.Hand)
+		}
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// playUntilOneHolder plays out until a single living player still holds
+// cards, and returns them.
+func playUntilOneHolder(t *testing.T, g *Game) *Player {
+	t.Helper()
+	for i := 0; g.Status == GameStatusInGame; i++ {
+		if i > 1000 {
+			t.Fatalf("game did not end")
+		}
+		var holders []*Player
+		for _, p := range g.Players {
+			if !p.Eliminated && len(p.Hand) > 0 {
+				holders = append(holders, p)
+			}
+		}
+		if g.playingOut() && len(holders) == 1 {
+			return holders[0]
+		}
+		if err := g.PlayScoreCard(g.CurrentPlayerID(), 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Fatalf("game ended before a single holder was left")
+	return nil
+}
+
+func TestPlayOutEndsWhenLastHolderLeaves(t *testing.T) {
+	g := startBlankDeck(t, DeckExhaustionPlayOut, 0)
+	p := playUntilOneHolder(t, g)
+	if p.Role != RoleGood {
+		t.Fatalf("seed no longer leaves a good player holding the last cards")
+	}
+	if err := g.RemovePlayer(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status != GameStatusFinished {
+		t.Fatalf("status=%s current=%q", g.Status, g.CurrentPlayerID())
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlayOutEndsWhenLastHolderIsVotedOut(t *testing.T) {
+	r := DefaultRules()
+	r.DeckExhaustion = DeckExhaustionPlayOut
+	r.MeetingsPerPlayer = 1
+	r.MeetingOutcome = MeetingOutcomeEject
+	g := startBlankDeckWithRules(t, r)
+	p := playUntilOneHolder(t, g)
+	if p.Role != RoleGood {
+		t.Fatalf("seed no longer leaves a good player holding the last cards")
+	}
+	if err := g.CallMeeting(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, voter := range g.Players {
+		if voter.ID != p.ID {
+			if err := g.CastVote(voter.ID, p.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := g.CloseMeeting(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status != GameStatusFinished {
+		t.Fatalf("status=%s current=%q", g.Status, g.CurrentPlayerID())
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNoPassingWhilePlayingOut(t *testing.T) {
+	r := DefaultRules()
+	r.DeckExhaustion = DeckExhaustionPlayOut
+	r.AllowPass = true
+	g := startBlankDeckWithRules(t, r)
+	if err := g.PassTurn(g.CurrentPlayerID()); err != nil {
+		t.Fatalf("pass before the pile runs out: %v", err)
+	}
+	for !g.playingOut() {
+		if err := g.PlayScoreCard(g.CurrentPlayerID(), 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.PassTurn(g.CurrentPlayerID()); !errors.Is(err, ErrActionDisabled) {
+		t.Fatalf("pass while playing out: %v", err)
+	}
+}
@@ -1,10 +1,6 @@
 package domain
 
-import (
-	"crypto/rand"
-	"math/big"
-)
-
+// Defaults used by DefaultRules.
 const (
 	MinPlayers = 3
 	MaxPlayers = 8
@@ -16,6 +12,9 @@ const (
 // Game contains all authoritative rules/state.
 // It is transport-agnostic and safe to test in isolation.
 type Game struct {
+	Rules GameRules
+	Deck  DeckSpec
+
 	Status  GameStatus
 	Winner  Winner
 	Players []*Player
@@ -26,11 +25,75 @@ type Game struct {
 	DrawPile    []Card
 	DiscardPile []Card
 
-	TurnIndex int // index within Players slice; skips eliminated players
+	TurnIndex  int // index within Players slice; skips eliminated players
+	Turn       int // number of turn changes since Start
+	Reshuffles int // times the discard pile was turned into the draw pile
+
+	Meeting     *Meeting       // set while Status is GameStatusMeeting
+	LastMeeting *MeetingResult // outcome of the most recent meeting
+
+	// Seed drives shuffling and role assignment. Zero until the game starts
+	// unless set with SetSeed; zero afterwards only if SetRandSource was used.
+	Seed uint64
+	rng  RandSource
+
+	// Events is the ordered log of successful actions since Start.
+	Events []Event
 }
 
 func NewLobbyGame() *Game {
-	return &Game{Status: GameStatusLobby, Winner: WinnerNone}
+	return &Game{Rules: DefaultRules(), Deck: ClassicDeck(), Status: GameStatusLobby, Winner: WinnerNone}
+}
+
+// SetRules replaces the rule set. Only allowed before the game starts.
+func (g *Game) SetRules(r GameRules) error {
+	if g.Status != GameStatusLobby {
+		return ErrAlreadyInGame
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if len(g.Players) > r.MaxPlayers {
+		return ErrTooManyPlayers
+	}
+	r.ImpostorBrackets = append([]ImpostorBracket(nil), r.ImpostorBrackets...)
+	g.Rules = r
+	return nil
+}
+
+// SetDeck replaces the deck composition. Only allowed before the game starts.
+// Deck size is checked against the actual player count in Start.
+func (g *Game) SetDeck(d DeckSpec) error {
+	if g.Status != GameStatusLobby {
+		return ErrAlreadyInGame
+	}
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	d.Cards = append([]DeckEntry(nil), d.Cards...)
+	g.Deck = d
+	return nil
+}
+
+// SetSeed makes the next Start deterministic. Only allowed before the game starts.
+func (g *Game) SetSeed(seed uint64) error {
+	if g.Status != GameStatusLobby {
+		return ErrAlreadyInGame
+	}
+	g.Seed = seed
+	g.rng = NewSeededSource(seed)
+	return nil
+}
+
+// SetRandSource injects a custom randomness source. The game is then not
+// reproducible from Seed, which is cleared.
+func (g *Game) SetRandSource(src RandSource) error {
+	if g.Status != GameStatusLobby {
+		return ErrAlreadyInGame
+	}
+	g.Seed = 0
+	g.rng = src
+	return nil
 }
 
 // AddPlayer adds a player to the lobby before the game starts.
@@ -41,7 +104,7 @@ func (g *Game) AddPlayer(p *Player) error {
 	if p == nil || p.ID == "" {
 		return ErrPlayerNotFound
 	}
-	if len(g.Players) >= MaxPlayers {
+	if len(g.Players) >= g.Rules.MaxPlayers {
 		return ErrTooManyPlayers
 	}
 	for _, existing := range g.Players {
@@ -53,40 +116,154 @@ func (g *Game) AddPlayer(p *Player) error {
 	return nil
 }
 
+// Reset returns a finished game to the lobby for a rematch. Seats, rules and
+// deck are kept; players who left are dropped and all per-game state is cleared.
+// The next Start draws a fresh seed.
+func (g *Game) Reset() error {
+	if g.Status != GameStatusFinished {
+		return ErrGameNotFinished
+	}
+	players := g.Players[:0]
+	for _, p := range g.Players {
+		if p == nil || p.Left {
+			continue
+		}
+		p.Role = ""
+		p.Hand = nil
+		p.Accusations = 0
+		p.Eliminated = false
+		p.Timeouts = 0
+		p.MeetingsCalled = 0
+		p.Shields = 0
+		p.KnownRoles = nil
+		players = append(players, p)
+	}
+	g.Players = players
+
+	g.Status = GameStatusLobby
+	g.Winner = WinnerNone
+	g.ChestScore = 0
+	g.GoalScore = 0
+	g.DrawPile = nil
+	g.DiscardPile = nil
+	g.TurnIndex = 0
+	g.Turn = 0
+	g.Reshuffles = 0
+	g.Meeting = nil
+	g.LastMeeting = nil
+	g.Seed = 0
+	g.rng = nil
+	g.Events = nil
+	return nil
+}
+
+// RemovePlayer takes a player out of the game.
+//
+// In the lobby the seat is freed. Once the game has started the seat is kept
+// so views and replays stay consistent: the player is marked as left and, if
+// still alive, eliminated; their hand is discarded and the turn moves on if it
+// was theirs.
+func (g *Game) RemovePlayer(playerID string) error {
+	p, err := g.mustPlayer(playerID)
+	if err != nil {
+		return err
+	}
+	switch g.Status {
+	case GameStatusLobby:
+		for i, existing := range g.Players {
+			if existing == p {
+				g.Players = append(g.Players[:i], g.Players[i+1:]...)
+				break
+			}
+		}
+		return nil
+	case GameStatusFinished:
+		p.Left = true
+		return nil
+	}
+
+	if p.Left {
+		return ErrPlayerNotFound
+	}
+	wasCurrent := g.CurrentPlayerID() == playerID
+	e := Event{Type: EventPlayerLeft, PlayerID: playerID}
+	p.Left = true
+	if !p.Eliminated {
+		p.Eliminated = true
+		e.Eliminated = []string{p.ID}
+	}
+	g.DiscardPile = append(g.DiscardPile, p.Hand...)
+	p.Hand = nil
+	if wasCurrent {
+		g.advanceTurn()
+	}
+	err = g.checkEndConditions()
+	g.record(e)
+	return err
+}
+
 // Start transitions the lobby into an active game, assigns roles, builds/shuffles the deck,
-// and deals StartingHandSize cards to each player.
+// and deals Rules.StartingHandSize cards to each player.
 func (g *Game) Start() error {
 	if g.Status != GameStatusLobby {
 		return ErrCannotStart
 	}
-	if len(g.Players) < MinPlayers {
+	if err := g.Rules.Validate(); err != nil {
+		return err
+	}
+	if len(g.Players) < g.Rules.MinPlayers {
 		return ErrNotEnoughPlayers
 	}
-	if len(g.Players) > MaxPlayers {
+	if len(g.Players) > g.Rules.MaxPlayers {
 		return ErrTooManyPlayers
 	}
+	if err := g.Deck.ValidateFor(len(g.Players), g.Rules); err != nil {
+		return err
+	}
+
+	if g.rng == nil {
+		// Default: a crypto-random seed, recorded so the game can be replayed.
+		g.Seed = NewSeed()
+		g.rng = NewSeededSource(g.Seed)
+	}
 
 	g.Status = GameStatusInGame
 	g.Winner = WinnerNone
+	g.Events = nil
 	g.ChestScore = 0
-	g.GoalScore = len(g.Players) + 6
+	g.GoalScore = g.Rules.GoalScore(len(g.Players))
 	g.TurnIndex = 0
+	g.Turn = 0
+	g.Reshuffles = 0
+	g.Meeting = nil
+	g.LastMeeting = nil
+
+	impostors := g.Rules.ImpostorCount(len(g.Players))
+	assignRoles(g.rng, g.Players, impostors)
 
-	impostors := impostorCount(len(g.Players))
-	assignRoles(g.Players, impostors)
+	g.DrawPile = g.Deck.Build(len(g.Players))
+	shuffleCards(g.rng, g.DrawPile)
 
-	g.DrawPile = buildDeck(len(g.Players))
-	shuffleCards(g.DrawPile)
+	rules, deck := g.Rules, g.Deck
+	started := Event{Type: EventGameStarted, Rules: &rules, Deck: &deck}
+	for _, p := range g.Players {
+		started.Players = append(started.Players, EventPlayer{ID: p.ID, Name: p.Name, Role: p.Role})
+	}
 
 	for _, p := range g.Players {
 		p.Accusations = 0
 		p.Eliminated = false
+		p.Timeouts = 0
+		p.MeetingsCalled = 0
+		p.Shields = 0
+		p.KnownRoles = nil
 		p.Hand = p.Hand[:0]
-		for i := 0; i < StartingHandSize; i++ {
+		for i := 0; i < g.Rules.StartingHandSize; i++ {
 			c, ok := g.drawOne()
 			if !ok {
 				// If deck is insufficient, end immediately.
 				g.finishByOver()
+				g.record(started)
 				return nil
 			}
 			p.Hand = append(p.Hand, c)
@@ -94,17 +271,12 @@ func (g *Game) Start() error {
 	}
 
 	g.normalizeTurnIndex()
-	return g.checkEndConditions()
-}
-
-func impostorCount(players int) int {
-	if players <= 5 {
-		return 1
-	}
-	return 2
+	err := g.checkEndConditions()
+	g.record(started)
+	return err
 }
 
-func assignRoles(players []*Player, impostors int) {
+func assignRoles(rng RandSource, players []*Player, impostors int) {
 	// Randomly select impostors.
 	idx := make([]int, 0, len(players))
 	for i := range players {
@@ -113,7 +285,7 @@ func assignRoles(players []*Player, impostors int) {
 	}
 	// Fisher-Yates partial shuffle for first impostors elements.
 	for i := 0; i < len(idx); i++ {
-		j := randInt(i, len(idx))
+		j := randInt(rng, i, len(idx))
 		idx[i], idx[j] = idx[j], idx[i]
 	}
 	for k := 0; k < impostors && k < len(idx); k++ {
@@ -121,54 +293,18 @@ func assignRoles(players []*Player, impostors int) {
 	}
 }
 
-// buildDeck defines the server-side authoritative deck.
-//
-// The game spec does not define exact deck composition, only the scoring card values
-// and the existence of accusation cards. This builder creates a balanced deck that scales
-// with player count and is large enough for longer games.
-func buildDeck(players int) []Card {
-	// Per player:
-	// - 6x +1
-	// - 4x  0
-	// - 3x -2
-	// - 3x accusation
-	// Total = 16 * players cards.
-	deck := make([]Card, 0, 16*players)
-	for i := 0; i < players; i++ {
-		for j := 0; j < 6; j++ {
-			deck = append(deck, Card{Type: CardTypeScore, Score: 1})
-		}
-		for j := 0; j < 4; j++ {
-			deck = append(deck, Card{Type: CardTypeScore, Score: 0})
-		}
-		for j := 0; j < 3; j++ {
-			deck = append(deck, Card{Type: CardTypeScore, Score: -2})
-		}
-		for j := 0; j < 3; j++ {
-			deck = append(deck, Card{Type: CardTypeAccusation})
-		}
-	}
-	return deck
-}
-
-func shuffleCards(cards []Card) {
+func shuffleCards(rng RandSource, cards []Card) {
 	for i := len(cards) - 1; i > 0; i-- {
-		j := randInt(0, i+1)
+		j := randInt(rng, 0, i+1)
 		cards[i], cards[j] = cards[j], cards[i]
 	}
 }
 
-func randInt(minInclusive, maxExclusive int) int {
+func randInt(rng RandSource, minInclusive, maxExclusive int) int {
 	if maxExclusive <= minInclusive {
 		return minInclusive
 	}
-	n := big.NewInt(int64(maxExclusive - minInclusive))
-	r, err := rand.Int(rand.Reader, n)
-	if err != nil {
-		// Very unlikely; deterministic fallback.
-		return minInclusive
-	}
-	return minInclusive + int(r.Int64())
+	return minInclusive + rng.IntN(maxExclusive-minInclusive)
 }
 
 // CurrentPlayerID returns the active player's ID.
@@ -178,52 +314,88 @@ func (g *Game) CurrentPlayerID() string {
 		return ""
 	}
 	p := g.Players[g.TurnIndex]
-	if p == nil || p.Eliminated {
+	if !g.canTakeTurn(p) {
 		return ""
 	}
 	return p.ID
 }
 
+// PlayScoreCard plays a score card; it fails with ErrInvalidCardType for any other card.
 func (g *Game) PlayScoreCard(playerID string, handIndex int) error {
-	if g.Status != GameStatusInGame {
-		if g.Status == GameStatusFinished {
-			return ErrGameFinished
-		}
-		return ErrInvalidState
-	}
-	p, err := g.mustPlayer(playerID)
+	return g.playCard(playerID, handIndex, "", CardTypeScore)
+}
+
+// PlayAccusationCard plays an accusation card against targetID.
+func (g *Game) PlayAccusationCard(playerID string, handIndex int, targetID string) error {
+	return g.playCard(playerID, handIndex, targetID, CardTypeAccusation)
+}
+
+// PlayCard plays any card from the hand; its registered CardEffect decides
+// what happens and whether targetID is required.
+func (g *Game) PlayCard(playerID string, handIndex int, targetID string) error {
+	return g.playCard(playerID, handIndex, targetID, "")
+}
+
+// playCard plays the card at handIndex, which must be of type want unless
+// want is empty.
+func (g *Game) playCard(playerID string, handIndex int, targetID string, want CardType) error {
+	p, err := g.requireTurn(playerID)
 	if err != nil {
 		return err
 	}
-	if p.Eliminated {
-		return ErrPlayerEliminated
+	if handIndex < 0 || handIndex >= len(p.Hand) {
+		return ErrInvalidHandIndex
 	}
-	if g.CurrentPlayerID() != playerID {
-		return ErrNotPlayersTurn
+	card := p.Hand[handIndex]
+	effect, ok := cardEffect(card.Type)
+	if !ok || (want != "" && card.Type != want) {
+		return ErrInvalidCardType
 	}
-	card, err := removeCard(&p.Hand, handIndex)
+	target, err := g.resolveTarget(p, effect.Target(), targetID)
 	if err != nil {
 		return err
 	}
-	if !card.IsScore() {
-		// Put it back to preserve hand order as much as possible.
-		p.Hand = insertCard(p.Hand, handIndex, card)
-		return ErrInvalidCardType
-	}
 
-	g.ChestScore += card.Score
+	// The card leaves the hand before it resolves, so effects that move
+	// cards between hands never see it.
+	if _, err := removeCard(&p.Hand, handIndex); err != nil {
+		return err
+	}
+	e := Event{Type: EventCardPlayed, PlayerID: playerID, HandIndex: handIndex, Card: &card, TargetID: targetID}
+	effect.Resolve(g, p, target, card, &e)
 	g.DiscardPile = append(g.DiscardPile, card)
 
-	g.afterPlayDrawAdvance(p)
-	return g.checkEndConditions()
+	g.afterPlayDrawAdvance(p, &e)
+	err = g.checkEndConditions()
+	g.record(e)
+	return err
 }
 
-func (g *Game) PlayAccusationCard(playerID string, handIndex int, targetID string) error {
-	if g.Status != GameStatusInGame {
-		if g.Status == GameStatusFinished {
-			return ErrGameFinished
-		}
-		return ErrInvalidState
+// resolveTarget checks targetID against what the card accepts.
+func (g *Game) resolveTarget(p *Player, mode TargetMode, targetID string) (*Player, error) {
+	switch {
+	case targetID == "" && mode == TargetOptional:
+		return p, nil
+	case targetID == "" && mode == TargetNone:
+		return nil, nil
+	case targetID == "" || mode == TargetNone:
+		return nil, ErrTargetInvalid
+	case targetID == p.ID && mode == TargetOther:
+		return nil, ErrTargetInvalid
+	}
+	target, err := g.mustPlayer(targetID)
+	if err != nil {
+		return nil, ErrTargetNotFound
+	}
+	if target.Eliminated {
+		return nil, ErrTargetInvalid
+	}
+	return target, nil
+}
+
+func (g *Game) CallOver(playerID string) error {
+	if err := g.requireInGame(); err != nil {
+		return err
 	}
 	p, err := g.mustPlayer(playerID)
 	if err != nil {
@@ -232,74 +404,144 @@ func (g *Game) PlayAccusationCard(playerID string, handIndex int, targetID strin
 	if p.Eliminated {
 		return ErrPlayerEliminated
 	}
-	if g.CurrentPlayerID() != playerID {
-		return ErrNotPlayersTurn
-	}
-	if targetID == "" || targetID == playerID {
-		return ErrTargetInvalid
+	if p.Role != RoleGood {
+		return ErrOnlyGoodCanCall
 	}
-	card, err := removeCard(&p.Hand, handIndex)
-	if err != nil {
-		return err
+	g.finishByOver()
+	g.record(Event{Type: EventOverCalled, PlayerID: playerID})
+	return nil
+}
+
+// TeamChat checks that playerID is an impostor and returns the impostors who
+// may read the message, sender included. The message is kept in the event log
+// so it is revealed in the replay once the game is over.
+func (g *Game) TeamChat(playerID, text string) ([]string, error) {
+	if g.Status == GameStatusFinished {
+		return nil, ErrGameFinished
 	}
-	if !card.IsAccusation() {
-		p.Hand = insertCard(p.Hand, handIndex, card)
-		return ErrInvalidCardType
+	if !g.Status.Playing() {
+		return nil, ErrInvalidState
 	}
-	target, err := g.mustPlayer(targetID)
+	p, err := g.mustPlayer(playerID)
 	if err != nil {
-		// restore card
-		p.Hand = insertCard(p.Hand, handIndex, card)
-		return ErrTargetNotFound
+		return nil, err
 	}
-	if target.Eliminated {
-		p.Hand = insertCard(p.Hand, handIndex, card)
-		return ErrTargetInvalid
+	if p.Role != RoleImpostor {
+		return nil, ErrNotImpostor
 	}
-
-	target.Accusations++
-	if target.Accusations >= AccusationsToEliminate {
-		target.Eliminated = true
+	var recipients []string
+	for _, other := range g.Players {
+		if other != nil && other.Role == RoleImpostor && !other.Left {
+			recipients = append(recipients, other.ID)
+		}
 	}
-
-	g.DiscardPile = append(g.DiscardPile, card)
-
-	g.afterPlayDrawAdvance(p)
-	return g.checkEndConditions()
+	g.record(Event{Type: EventTeamChat, PlayerID: playerID, Text: text})
+	return recipients, nil
 }
 
-func (g *Game) CallOver(playerID string) error {
-	if g.Status != GameStatusInGame {
-		if g.Status == GameStatusFinished {
-			return ErrGameFinished
-		}
-		return ErrInvalidState
+// HandleTurnTimeout applies Rules.TimeoutAction for the current player, whose
+// turn timer expired, or eliminates them after Rules.TimeoutsToEliminate timeouts.
+func (g *Game) HandleTurnTimeout(playerID string) error {
+	if err := g.requireInGame(); err != nil {
+		return err
 	}
 	p, err := g.mustPlayer(playerID)
 	if err != nil {
 		return err
 	}
-	if p.Eliminated {
-		return ErrPlayerEliminated
+	if g.CurrentPlayerID() != playerID {
+		return ErrNotPlayersTurn
 	}
-	if p.Role != RoleGood {
-		return ErrOnlyGoodCanCall
+
+	p.Timeouts++
+	e := Event{Type: EventTurnTimeout, PlayerID: playerID}
+	switch {
+	case g.Rules.TimeoutsToEliminate > 0 && p.Timeouts >= g.Rules.TimeoutsToEliminate:
+		p.Eliminated = true
+		e.Eliminated = []string{p.ID}
+		g.advanceTurn()
+	case g.Rules.TimeoutAction == TimeoutActionPlayRandom && hasScoreCard(p.Hand):
+		var scores []int
+		for i, c := range p.Hand {
+			if c.IsScore() {
+				scores = append(scores, i)
+			}
+		}
+		e.HandIndex = scores[randInt(g.rng, 0, len(scores))]
+		card, _ := removeCard(&p.Hand, e.HandIndex)
+		e.Card = &card
+		g.ChestScore += card.Score
+		g.DiscardPile = append(g.DiscardPile, card)
+		g.afterPlayDrawAdvance(p, &e)
+	default:
+		g.advanceTurn()
+	}
+	err = g.checkEndConditions()
+	g.record(e)
+	return err
+}
+
+func hasScoreCard(hand []Card) bool {
+	for _, c := range hand {
+		if c.IsScore() {
+			return true
+		}
 	}
-	g.finishByOver()
-	return nil
+	return false
 }
 
-func (g *Game) afterPlayDrawAdvance(current *Player) {
-	// Draw 1 card. If the draw pile is empty, the game ends as "over".
+// afterPlayDrawAdvance records the draw outcome on e.
+//
+// When the draw pile is empty, Rules.DeckExhaustion decides whether the game
+// ends as "over", reshuffles the discard pile, or carries on without drawing
+// until every living player's hand is empty.
+func (g *Game) afterPlayDrawAdvance(current *Player, e *Event) {
+	if len(g.DrawPile) == 0 && g.Rules.DeckExhaustion == DeckExhaustionReshuffle &&
+		g.Reshuffles < g.Rules.MaxReshuffles && len(g.DiscardPile) > 0 {
+		g.reshuffle()
+		e.Reshuffled = true
+	}
 	if c, ok := g.drawOne(); ok {
 		current.Hand = append(current.Hand, c)
+		e.Drawn = &c
 	} else {
-		g.finishByOver()
-		return
+		e.DeckEmpty = true
+		if !g.playingOut() || g.handsEmpty() {
+			g.finishByOver()
+			return
+		}
 	}
 	g.advanceTurn()
 }
 
+// reshuffle turns the discard pile into a new draw pile.
+func (g *Game) reshuffle() {
+	g.DrawPile = append(g.DrawPile, g.DiscardPile...)
+	g.DiscardPile = nil
+	shuffleCards(g.rng, g.DrawPile)
+	g.Reshuffles++
+}
+
+// playingOut reports whether players keep taking turns without drawing.
+func (g *Game) playingOut() bool {
+	return g.Rules.DeckExhaustion == DeckExhaustionPlayOut && len(g.DrawPile) == 0
+}
+
+func (g *Game) handsEmpty() bool {
+	for _, p := range g.Players {
+		if p != nil && !p.Eliminated && len(p.Hand) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// canTakeTurn reports whether p can be the current player. Once playing out,
+// players with empty hands are skipped.
+func (g *Game) canTakeTurn(p *Player) bool {
+	return p != nil && !p.Eliminated && (len(p.Hand) > 0 || !g.playingOut())
+}
+
 func (g *Game) drawOne() (Card, bool) {
 	if len(g.DrawPile) == 0 {
 		return Card{}, false
@@ -314,6 +556,7 @@ func (g *Game) advanceTurn() {
 	if len(g.Players) == 0 {
 		return
 	}
+	g.Turn++
 	g.TurnIndex = (g.TurnIndex + 1) % len(g.Players)
 	g.normalizeTurnIndex()
 }
@@ -323,16 +566,15 @@ func (g *Game) normalizeTurnIndex() {
 		g.TurnIndex = 0
 		return
 	}
-	// Ensure TurnIndex points at an active player.
+	// Ensure TurnIndex points at a player who can take a turn.
 	for i := 0; i < len(g.Players); i++ {
 		idx := (g.TurnIndex + i) % len(g.Players)
-		p := g.Players[idx]
-		if p != nil && !p.Eliminated {
+		if g.canTakeTurn(g.Players[idx]) {
 			g.TurnIndex = idx
 			return
 		}
 	}
-	// All eliminated: keep 0.
+	// Nobody can play: keep 0.
 	g.TurnIndex = 0
 }
 
@@ -345,10 +587,28 @@ func (g *Game) finishByOver() {
 	}
 }
 
+// requireInGame reports why actions are not allowed in the current status.
+func (g *Game) requireInGame() error {
+	switch g.Status {
+	case GameStatusInGame:
+		return nil
+	case GameStatusFinished:
+		return ErrGameFinished
+	case GameStatusMeeting:
+		return ErrMeetingInProgress
+	}
+	return ErrInvalidState
+}
+
 func (g *Game) checkEndConditions() error {
-	if g.Status != GameStatusInGame {
+	if !g.Status.Playing() {
 		return nil
 	}
+	defer func() {
+		if g.Status == GameStatusFinished {
+			g.Meeting = nil
+		}
+	}()
 	// If all impostors are eliminated => good wins.
 	aliveImpostors := 0
 	alivePlayers := 0
@@ -371,6 +631,18 @@ func (g *Game) checkEndConditions() error {
 		g.Winner = WinnerGood
 		return nil
 	}
+	// Optional variant: impostors win once they match the living good players.
+	if g.Rules.ImpostorParityWins && aliveImpostors >= alivePlayers-aliveImpostors {
+		g.Status = GameStatusFinished
+		g.Winner = WinnerImpostor
+		return nil
+	}
+	// Playing out and the last cards left the table with a player who left
+	// or was voted out: nobody can take a turn any more.
+	if g.playingOut() && g.handsEmpty() {
+		g.finishByOver()
+		return nil
+	}
 	return nil
 }
 
@@ -397,16 +669,3 @@ func removeCard(hand *[]Card, index int) (Card, error) {
 	*hand = h
 	return c, nil
 }
-
-func insertCard(hand []Card, index int, c Card) []Card {
-	if index < 0 {
-		index = 0
-	}
-	if index > len(hand) {
-		index = len(hand)
-	}
-	hand = append(hand, Card{})
-	copy(hand[index+1:], hand[index:])
-	hand[index] = c
-	return hand
-}
@@ -11,7 +11,7 @@ func TestImpostorCount(t *testing.T) {
 		{6, 2}, {7, 2}, {8, 2},
 	}
 	for _, tc := range cases {
-		if got := impostorCount(tc.players); got != tc.want {
+		if got := DefaultRules().ImpostorCount(tc.players); got != tc.want {
 			t.Fatalf("players=%d got=%d want=%d", tc.players, got, tc.want)
 		}
 	}
@@ -72,3 +72,31 @@ func TestAccusationEliminatesAtThree(t *testing.T) {
 		t.Fatalf("accusations=%d", b.Accusations)
 	}
 }
+
+func TestResetKeepsSeatsAndClearsGame(t *testing.T) {
+	g := startSeeded(t, 17)
+	if err := g.Reset(); err != ErrGameNotFinished {
+		t.Fatalf("err=%v", err)
+	}
+	playOut(t, g)
+	if err := g.RemovePlayer("b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status != GameStatusLobby || len(g.Events) != 0 || g.Seed != 0 {
+		t.Fatalf("status=%s events=%d seed=%d", g.Status, len(g.Events), g.Seed)
+	}
+	if len(g.Players) != 5 {
+		t.Fatalf("players=%d", len(g.Players))
+	}
+	for _, p := range g.Players {
+		if p.ID == "b" || p.Role != "" || len(p.Hand) != 0 || p.Eliminated || p.Accusations != 0 {
+			t.Fatalf("player not reset: %+v", p)
+		}
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestRemovePlayerInLobbyFreesSeat(t *testing.T) {
+	g := NewLobbyGame()
+	g.Players = []*Player{{ID: "a"}, {ID: "b"}}
+	if err := g.RemovePlayer("a"); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Players) != 1 || g.Players[0].ID != "b" {
+		t.Fatalf("players=%+v", g.Players)
+	}
+}
+
+func TestRemovePlayerMidGameEliminatesAndAdvances(t *testing.T) {
+	g := startSeeded(t, 21)
+	id := g.CurrentPlayerID()
+	p, _ := g.mustPlayer(id)
+	if err := g.RemovePlayer(id); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Left || !p.Eliminated || len(p.Hand) != 0 {
+		t.Fatalf("player=%+v", p)
+	}
+	if g.Status == GameStatusInGame && g.CurrentPlayerID() == id {
+		t.Fatalf("turn still on departed player")
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoveLastImpostorEndsGame(t *testing.T) {
+	g := startSeeded(t, 8)
+	for _, p := range g.Players {
+		if p.Role == RoleImpostor {
+			if err := g.RemovePlayer(p.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if g.Status != GameStatusFinished || g.Winner != WinnerGood {
+		t.Fatalf("status=%s winner=%s", g.Status, g.Winner)
+	}
+}
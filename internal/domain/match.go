@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MatchConfig describes a best-of-N session made of several games.
+type MatchConfig struct {
+	Rounds int `json:"rounds"`
+	// TargetScore ends the match early once a player reaches it; 0 disables it.
+	TargetScore int `json:"targetScore"`
+
+	// Points awarded to every member of the winning team.
+	GoodWinPoints     int `json:"goodWinPoints"`
+	ImpostorWinPoints int `json:"impostorWinPoints"`
+}
+
+func (c MatchConfig) Validate() error {
+	if c.Rounds < 1 {
+		return fmt.Errorf("%w: rounds must be positive", ErrInvalidMatch)
+	}
+	if c.TargetScore < 0 || c.GoodWinPoints < 0 || c.ImpostorWinPoints < 0 {
+		return fmt.Errorf("%w: scores must not be negative", ErrInvalidMatch)
+	}
+	return nil
+}
+
+// Match runs several rounds of the same Game, re-dealing roles each round and
+// keeping a cumulative score per player.
+type Match struct {
+	Config MatchConfig
+	Game   *Game
+
+	Completed int            // rounds played to the end
+	Scores    map[string]int // playerID -> points
+	Finished  bool
+
+	counted bool // the current finished game was already scored
+}
+
+func NewMatch(g *Game, cfg MatchConfig) (*Match, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Match{Config: cfg, Game: g, Scores: make(map[string]int)}, nil
+}
+
+// RecordRound scores the game once it is finished. It is safe to call after
+// every action and reports whether a round was scored by this call.
+func (m *Match) RecordRound() bool {
+	if m.Game.Status != GameStatusFinished {
+		m.counted = false
+		return false
+	}
+	if m.counted || m.Finished {
+		return false
+	}
+	m.counted = true
+	m.Completed++
+
+	for _, p := range m.Game.Players {
+		if p == nil {
+			continue
+		}
+		switch {
+		case m.Game.Winner == WinnerGood && p.Role == RoleGood:
+			m.Scores[p.ID] += m.Config.GoodWinPoints
+		case m.Game.Winner == WinnerImpostor && p.Role == RoleImpostor:
+			m.Scores[p.ID] += m.Config.ImpostorWinPoints
+		}
+	}
+
+	if m.Completed >= m.Config.Rounds {
+		m.Finished = true
+	}
+	if m.Config.TargetScore > 0 {
+		for _, pts := range m.Scores {
+			if pts >= m.Config.TargetScore {
+				m.Finished = true
+			}
+		}
+	}
+	return true
+}
+
+// NextRound resets the finished game and starts the next round.
+func (m *Match) NextRound() error {
+	if m.Finished {
+		return ErrMatchFinished
+	}
+	if err := m.Game.Reset(); err != nil {
+		return err
+	}
+	return m.Game.Start()
+}
+
+// MatchStanding is one leaderboard row.
+type MatchStanding struct {
+	PlayerID string `json:"playerId"`
+	Name     string `json:"name"`
+	Points   int    `json:"points"`
+}
+
+// MatchView is the match progress shown to clients.
+type MatchView struct {
+	Round       int             `json:"round"`
+	Rounds      int             `json:"rounds"`
+	TargetScore int             `json:"targetScore"`
+	Finished    bool            `json:"finished"`
+	Leaderboard []MatchStanding `json:"leaderboard"`
+}
+
+func (m *Match) View() *MatchView {
+	round := m.Completed
+	if !m.Finished && m.Game.Status != GameStatusFinished {
+		round++
+	}
+	v := &MatchView{
+		Round:       round,
+		Rounds:      m.Config.Rounds,
+		TargetScore: m.Config.TargetScore,
+		Finished:    m.Finished,
+		Leaderboard: make([]MatchStanding, 0, len(m.Game.Players)),
+	}
+	for _, p := range m.Game.Players {
+		if p == nil {
+			continue
+		}
+		v.Leaderboard = append(v.Leaderboard, MatchStanding{PlayerID: p.ID, Name: p.Name, Points: m.Scores[p.ID]})
+	}
+	sort.SliceStable(v.Leaderboard, func(i, j int) bool {
+		return v.Leaderboard[i].Points > v.Leaderboard[j].Points
+	})
+	return v
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "testing"
+
+func TestMatchScoresRoundsAndEnds(t *testing.T) {
+	g := startSeeded(t, 31)
+	m, err := NewMatch(g, MatchConfig{Rounds: 2, GoodWinPoints: 1, ImpostorWinPoints: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for round := 1; round <= 2; round++ {
+		playOut(t, g)
+		if !m.RecordRound() {
+			t.Fatalf("round %d not recorded", round)
+		}
+		if m.RecordRound() {
+			t.Fatalf("round %d recorded twice", round)
+		}
+		for _, p := range g.Players {
+			won := (g.Winner == WinnerGood && p.Role == RoleGood) || (g.Winner == WinnerImpostor && p.Role == RoleImpostor)
+			if won && m.Scores[p.ID] == 0 {
+				t.Fatalf("winner %s got no points", p.ID)
+			}
+		}
+		if round == 1 {
+			if m.Finished {
+				t.Fatalf("finished after one round")
+			}
+			if err := m.NextRound(); err != nil {
+				t.Fatal(err)
+			}
+			m.RecordRound()
+		}
+	}
+	if !m.Finished {
+		t.Fatalf("match not finished after 2 rounds")
+	}
+	if err := m.NextRound(); err != ErrMatchFinished {
+		t.Fatalf("err=%v", err)
+	}
+	view := m.View()
+	if view.Round != 2 || len(view.Leaderboard) != len(g.Players) {
+		t.Fatalf("view=%+v", view)
+	}
+	for i := 1; i < len(view.Leaderboard); i++ {
+		if view.Leaderboard[i].Points > view.Leaderboard[i-1].Points {
+			t.Fatalf("leaderboard not sorted: %+v", view.Leaderboard)
+		}
+	}
+}
+
+func TestMatchEndsAtTargetScore(t *testing.T) {
+	g := startSeeded(t, 32)
+	m, _ := NewMatch(g, MatchConfig{Rounds: 10, TargetScore: 1, GoodWinPoints: 1, ImpostorWinPoints: 1})
+	playOut(t, g)
+	m.RecordRound()
+	if g.Winner != WinnerNone && !m.Finished {
+		t.Fatalf("match should end once someone reaches the target")
+	}
+}
@@ -0,0 +1,193 @@
+package domain
+
+// Meeting is an open vote. While it runs the game is in GameStatusMeeting and
+// no cards can be played.
+type Meeting struct {
+	Seq      int // of the meeting_called event; identifies the meeting
+	CalledBy string
+	Votes    map[string]string // voter ID to target ID; "" skips
+}
+
+// MeetingResult is the outcome of a closed meeting, public once it is over.
+type MeetingResult struct {
+	CalledBy   string            `json:"calledBy"`
+	TargetID   string            `json:"targetId,omitempty"` // empty when the vote was tied or skipped
+	Votes      map[string]string `json:"votes"`
+	Eliminated []string          `json:"eliminated,omitempty"`
+}
+
+// MeetingView is the public state of an open meeting. Ballots stay secret
+// until it closes; only who has voted is shown.
+type MeetingView struct {
+	CalledBy string   `json:"calledBy"`
+	Voted    []string `json:"voted"`
+}
+
+// CallMeeting pauses turns and opens a vote. Any living player may call one,
+// up to Rules.MeetingsPerPlayer times per game.
+func (g *Game) CallMeeting(playerID string) error {
+	if err := g.requireInGame(); err != nil {
+		return err
+	}
+	p, err := g.mustPlayer(playerID)
+	if err != nil {
+		return err
+	}
+	if p.Eliminated {
+		return ErrPlayerEliminated
+	}
+	if p.MeetingsCalled >= g.Rules.MeetingsPerPlayer {
+		return ErrNoMeetingsLeft
+	}
+	p.MeetingsCalled++
+	g.Status = GameStatusMeeting
+	g.Meeting = &Meeting{Seq: len(g.Events), CalledBy: playerID, Votes: make(map[string]string)}
+	g.record(Event{Type: EventMeetingCalled, PlayerID: playerID})
+	return nil
+}
+
+// CastVote records playerID's ballot against targetID, or a skip when
+// targetID is empty. Ballots are final.
+func (g *Game) CastVote(playerID, targetID string) error {
+	if err := g.requireMeeting(); err != nil {
+		return err
+	}
+	p, err := g.mustPlayer(playerID)
+	if err != nil {
+		return err
+	}
+	if p.Eliminated {
+		return ErrPlayerEliminated
+	}
+	if _, ok := g.Meeting.Votes[playerID]; ok {
+		return ErrAlreadyVoted
+	}
+	if targetID != "" {
+		if targetID == playerID {
+			return ErrTargetInvalid
+		}
+		target, err := g.mustPlayer(targetID)
+		if err != nil {
+			return ErrTargetNotFound
+		}
+		if target.Eliminated {
+			return ErrTargetInvalid
+		}
+	}
+	g.Meeting.Votes[playerID] = targetID
+	g.record(Event{Type: EventVoteCast, PlayerID: playerID, TargetID: targetID})
+	return nil
+}
+
+// MeetingComplete reports whether every living player has voted, so the
+// meeting can be closed before its time window runs out.
+func (g *Game) MeetingComplete() bool {
+	if g.Status != GameStatusMeeting || g.Meeting == nil {
+		return false
+	}
+	for _, p := range g.Players {
+		if p == nil || p.Eliminated {
+			continue
+		}
+		if _, ok := g.Meeting.Votes[p.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// CloseMeeting counts the ballots and resumes play. The target with the most
+// votes gets Rules.MeetingOutcome applied; a tie, or as many skips as the
+// leading target, spares everyone. Players who did not vote are ignored.
+func (g *Game) CloseMeeting() error {
+	if err := g.requireMeeting(); err != nil {
+		return err
+	}
+	result := &MeetingResult{CalledBy: g.Meeting.CalledBy, Votes: make(map[string]string)}
+	tally := make(map[string]int)
+	for voter, target := range g.Meeting.Votes {
+		// Ballots of players eliminated since they voted no longer count.
+		if p, err := g.mustPlayer(voter); err != nil || p.Eliminated {
+			continue
+		}
+		result.Votes[voter] = target
+		tally[target]++
+	}
+	best, tied := 0, false
+	for _, p := range g.Players {
+		if p == nil || p.Eliminated {
+			continue
+		}
+		n := tally[p.ID]
+		switch {
+		case n > best:
+			best, tied = n, false
+			result.TargetID = p.ID
+		case n == best && n > 0:
+			tied = true
+		}
+	}
+	if tied || best == 0 || tally[""] >= best {
+		result.TargetID = ""
+	}
+
+	e := Event{Type: EventMeetingClosed, PlayerID: result.CalledBy, TargetID: result.TargetID}
+	if len(result.Votes) > 0 {
+		e.Votes = make(map[string]string, len(result.Votes))
+		for k, v := range result.Votes {
+			e.Votes[k] = v
+		}
+	}
+	if result.TargetID != "" {
+		target, _ := g.mustPlayer(result.TargetID)
+		wasCurrent := g.CurrentPlayerID() == target.ID
+		if g.Rules.MeetingOutcome == MeetingOutcomeEject {
+			target.Eliminated = true
+		} else {
+			target.Accusations++
+			if target.Accusations >= g.Rules.AccusationsToEliminate {
+				target.Eliminated = true
+			}
+		}
+		if target.Eliminated {
+			result.Eliminated = []string{target.ID}
+			e.Eliminated = []string{target.ID}
+			if wasCurrent {
+				g.advanceTurn()
+			}
+		}
+	}
+
+	g.Status = GameStatusInGame
+	g.Meeting = nil
+	g.LastMeeting = result
+	err := g.checkEndConditions()
+	g.record(e)
+	return err
+}
+
+func (g *Game) requireMeeting() error {
+	switch g.Status {
+	case GameStatusMeeting:
+		return nil
+	case GameStatusFinished:
+		return ErrGameFinished
+	}
+	return ErrNoMeeting
+}
+
+func (g *Game) meetingView() *MeetingView {
+	if g.Meeting == nil {
+		return nil
+	}
+	v := &MeetingView{CalledBy: g.Meeting.CalledBy, Voted: []string{}}
+	for _, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		if _, ok := g.Meeting.Votes[p.ID]; ok {
+			v.Voted = append(v.Voted, p.ID)
+		}
+	}
+	return v
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func startWithMeetings(t *testing.T, outcome MeetingOutcome) *Game {
+	t.Helper()
+	g := NewLobbyGame()
+	r := DefaultRules()
+	r.MeetingsPerPlayer = 1
+	r.MeetingOutcome = outcome
+	if err := g.SetRules(r); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := g.AddPlayer(&Player{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.SetSeed(5); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestMeetingPausesTurnsAndAccuses(t *testing.T) {
+	g := startWithMeetings(t, MeetingOutcomeAccuse)
+	if err := g.CallMeeting("a"); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status != GameStatusMeeting {
+		t.Fatalf("status=%s", g.Status)
+	}
+	if err := g.PlayScoreCard(g.CurrentPlayerID(), 0); !errors.Is(err, ErrMeetingInProgress) {
+		t.Fatalf("play during meeting: %v", err)
+	}
+	if err := g.CallMeeting("b"); !errors.Is(err, ErrMeetingInProgress) {
+		t.Fatalf("second meeting: %v", err)
+	}
+
+	for voter, target := range map[string]string{"a": "d", "b": "d", "c": "", "d": "a"} {
+		if err := g.CastVote(voter, target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.CastVote("a", "b"); !errors.Is(err, ErrAlreadyVoted) {
+		t.Fatalf("double vote: %v", err)
+	}
+	if !g.MeetingComplete() {
+		t.Fatalf("meeting should be complete")
+	}
+	if err := g.CloseMeeting(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, _ := g.mustPlayer("d")
+	if g.Status != GameStatusInGame || d.Accusations != 1 || d.Eliminated {
+		t.Fatalf("status=%s d=%+v", g.Status, d)
+	}
+	if g.LastMeeting == nil || g.LastMeeting.TargetID != "d" || len(g.LastMeeting.Votes) != 4 {
+		t.Fatalf("result=%+v", g.LastMeeting)
+	}
+	if err := g.CallMeeting("a"); !errors.Is(err, ErrNoMeetingsLeft) {
+		t.Fatalf("meeting limit: %v", err)
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMeetingTieSparesEveryone(t *testing.T) {
+	g := startWithMeetings(t, MeetingOutcomeEject)
+	if err := g.CallMeeting("b"); err != nil {
+		t.Fatal(err)
+	}
+	_ = g.CastVote("a", "b")
+	_ = g.CastVote("b", "a")
+	if g.MeetingComplete() {
+		t.Fatalf("meeting complete with missing ballots")
+	}
+	if err := g.CloseMeeting(); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range g.Players {
+		if p.Eliminated {
+			t.Fatalf("%s eliminated on a tie", p.ID)
+		}
+	}
+	if g.LastMeeting.TargetID != "" {
+		t.Fatalf("target=%q", g.LastMeeting.TargetID)
+	}
+}
+
+func TestMeetingEjectCanEndGame(t *testing.T) {
+	g := startWithMeetings(t, MeetingOutcomeEject)
+	var impostor string
+	for _, p := range g.Players {
+		if p.Role == RoleImpostor {
+			impostor = p.ID
+		}
+	}
+	if err := g.CallMeeting(impostor); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range g.Players {
+		if p.ID != impostor {
+			if err := g.CastVote(p.ID, impostor); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := g.CloseMeeting(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status != GameStatusFinished || g.Winner != WinnerGood || g.Meeting != nil {
+		t.Fatalf("status=%s winner=%s", g.Status, g.Winner)
+	}
+	if err := VerifyReplay(g); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMeetingsDisabledByDefault(t *testing.T) {
+	g := startSeeded(t, 1)
+	if err := g.CallMeeting("a"); !errors.Is(err, ErrNoMeetingsLeft) {
+		t.Fatalf("err=%v", err)
+	}
+	if err := g.CastVote("a", "b"); !errors.Is(err, ErrNoMeeting) {
+		t.Fatalf("err=%v", err)
+	}
+}
@@ -8,6 +8,13 @@ type Player struct {
 	Hand        []Card `json:"-"` // never serialize directly (private information)
 	Accusations int    `json:"accusations"`
 	Eliminated  bool   `json:"eliminated"`
+	Timeouts    int    `json:"timeouts"` // expired turn timers this game
+	Left        bool   `json:"left"`     // left mid-game; the seat is kept as eliminated
+
+	MeetingsCalled int `json:"meetingsCalled"`
+
+	Shields    int             `json:"-"` // accusations the player will block; private
+	KnownRoles map[string]Role `json:"-"` // roles learned from peek cards; private
 }
 
 func (p *Player) Active() bool {
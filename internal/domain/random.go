@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	mrand "math/rand/v2"
+)
+
+// RandSource provides the randomness used for shuffling and role assignment.
+type RandSource interface {
+	// IntN returns a uniform int in [0, n). n must be positive.
+	IntN(n int) int
+}
+
+// NewSeededSource returns a deterministic RandSource: the same seed always
+// produces the same deal.
+func NewSeededSource(seed uint64) RandSource {
+	return mrand.New(mrand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// NewSeed returns a fresh seed read from crypto/rand.
+func NewSeed() uint64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func startSeeded(t *testing.T, seed uint64) *Game {
+	t.Helper()
+	g := NewLobbyGame()
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		if err := g.AddPlayer(&Player{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.SetSeed(seed); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestSeededStartIsDeterministic(t *testing.T) {
+	g1 := startSeeded(t, 42)
+	g2 := startSeeded(t, 42)
+	if !reflect.DeepEqual(g1.DrawPile, g2.DrawPile) {
+		t.Fatalf("draw piles differ")
+	}
+	for i := range g1.Players {
+		if g1.Players[i].Role != g2.Players[i].Role {
+			t.Fatalf("roles differ for %s", g1.Players[i].ID)
+		}
+		if !reflect.DeepEqual(g1.Players[i].Hand, g2.Players[i].Hand) {
+			t.Fatalf("hands differ for %s", g1.Players[i].ID)
+		}
+	}
+}
+
+func TestStartRecordsSeed(t *testing.T) {
+	g := NewLobbyGame()
+	g.Players = []*Player{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Seed == 0 {
+		t.Fatalf("seed not recorded")
+	}
+}
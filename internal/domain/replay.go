@@ -0,0 +1,92 @@
+package domain
+
+// ReplayVersion is bumped whenever ReplayDocument changes incompatibly.
+const ReplayVersion = 1
+
+// ReplayPlayer is a seat with its role and dealt hand.
+type ReplayPlayer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Role        Role   `json:"role"`
+	InitialHand []Card `json:"initialHand"`
+}
+
+// ReplayResult summarizes how the game ended.
+type ReplayResult struct {
+	Winner     Winner `json:"winner"`
+	ChestScore int    `json:"chestScore"`
+	GoalScore  int    `json:"goalScore"`
+	Turns      int    `json:"turns"` // turn changes, as counted by Game.Turn
+}
+
+// ReplayDocument is the exportable record of a finished game.
+type ReplayDocument struct {
+	Version   int            `json:"version"`
+	LobbyCode string         `json:"lobbyCode"`
+	Seed      uint64         `json:"seed"`
+	Players   []ReplayPlayer `json:"players"`
+	Events    []Event        `json:"events"`
+	Result    ReplayResult   `json:"result"`
+}
+
+// ReplayDocument exports the game. Only finished games can be exported.
+func (g *Game) ReplayDocument(lobbyCode string) (ReplayDocument, error) {
+	if g.Status != GameStatusFinished || len(g.Events) == 0 {
+		return ReplayDocument{}, ErrGameNotFinished
+	}
+	initial, err := replayN(g.Seed, g.Events, 1)
+	if err != nil {
+		return ReplayDocument{}, err
+	}
+	doc := ReplayDocument{
+		Version:   ReplayVersion,
+		LobbyCode: lobbyCode,
+		Seed:      g.Seed,
+		Players:   make([]ReplayPlayer, 0, len(initial.Players)),
+		Events:    append([]Event(nil), g.Events...),
+		Result: ReplayResult{
+			Winner:     g.Winner,
+			ChestScore: g.ChestScore,
+			GoalScore:  g.GoalScore,
+			Turns:      g.Turn,
+		},
+	}
+	for _, p := range initial.Players {
+		doc.Players = append(doc.Players, ReplayPlayer{
+			ID:          p.ID,
+			Name:        p.Name,
+			Role:        p.Role,
+			InitialHand: append([]Card(nil), p.Hand...),
+		})
+	}
+	return doc, nil
+}
+
+// ViewAt returns the state at the end of turn (0 is the initial deal).
+// Events that do not change the turn, such as chat, votes and meetings, are
+// shown with the turn they happened in. An empty playerID yields an
+// omniscient view.
+func (d ReplayDocument) ViewAt(turn int, playerID string) (GameView, error) {
+	if turn < 0 || turn > d.Result.Turns {
+		return GameView{}, ErrTurnOutOfRange
+	}
+	// Turn i ends right before the event that starts turn i+1.
+	n := len(d.Events)
+	seen := 0
+	if _, err := replayEach(d.Seed, d.Events, len(d.Events), func(g *Game) {
+		if g.Turn > turn && n == len(d.Events) {
+			n = seen
+		}
+		seen++
+	}); err != nil {
+		return GameView{}, err
+	}
+	g, err := replayN(d.Seed, d.Events, n)
+	if err != nil {
+		return GameView{}, err
+	}
+	if playerID == "" {
+		return g.OmniscientView(d.LobbyCode), nil
+	}
+	return g.ViewFor(playerID, d.LobbyCode)
+}
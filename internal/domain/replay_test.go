@@ -0,0 +1,112 @@
+package domain
+
+import "testing"
+
+func TestReplayDocument(t *testing.T) {
+	g := startSeeded(t, 5)
+	if _, err := g.ReplayDocument("ABC"); err != ErrGameNotFinished {
+		t.Fatalf("err=%v", err)
+	}
+	playOut(t, g)
+
+	doc, err := g.ReplayDocument("ABC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Version != ReplayVersion || doc.Result.Winner != g.Winner || len(doc.Players) != len(g.Players) {
+		t.Fatalf("doc=%+v", doc)
+	}
+	for _, p := range doc.Players {
+		if len(p.InitialHand) != g.Rules.StartingHandSize {
+			t.Fatalf("initial hand=%d", len(p.InitialHand))
+		}
+	}
+
+	first, err := doc.ViewAt(0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Players[0].Role == "" || len(first.Players[0].Hand) != g.Rules.StartingHandSize {
+		t.Fatalf("omniscient view hides state: %+v", first.Players[0])
+	}
+	last, err := doc.ViewAt(doc.Result.Turns, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Status != GameStatusFinished || last.ChestScore != g.ChestScore || last.You.ID != "a" {
+		t.Fatalf("last=%+v", last)
+	}
+	if _, err := doc.ViewAt(doc.Result.Turns+1, ""); err != ErrTurnOutOfRange {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestReplayTurnsSkipChatAndMeetings(t *testing.T) {
+	g := startWithMeetings(t, MeetingOutcomeAccuse)
+	play := func() int {
+		t.Helper()
+		id := g.CurrentPlayerID()
+		p, _ := g.mustPlayer(id)
+		var err error
+		if p.Hand[0].IsAccusation() {
+			err = g.PlayAccusationCard(id, 0, firstOtherActive(g, id))
+		} else {
+			err = g.PlayScoreCard(id, 0)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g.ChestScore
+	}
+
+	chest := []int{g.ChestScore, play()}
+	for _, p := range g.Players {
+		if p.Role == RoleImpostor {
+			if _, err := g.TeamChat(p.ID, "wait"); err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if err := g.CallMeeting("a"); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range g.Players {
+		if err := g.CastVote(p.ID, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.CloseMeeting(); err != nil {
+		t.Fatal(err)
+	}
+	chest = append(chest, play())
+	playOut(t, g)
+
+	doc, err := g.ReplayDocument("ABC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Result.Turns != g.Turn || doc.Result.Turns >= len(doc.Events)-1 {
+		t.Fatalf("turns=%d game turn=%d events=%d", doc.Result.Turns, g.Turn, len(doc.Events))
+	}
+	for turn, want := range chest {
+		view, err := doc.ViewAt(turn, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if view.ChestScore != want || view.Status != GameStatusInGame {
+			t.Fatalf("turn %d: chest=%d want %d status=%s", turn, view.ChestScore, want, view.Status)
+		}
+		// The meeting was held during turn 1.
+		if held := view.LastMeeting != nil; held != (turn >= 1) {
+			t.Fatalf("turn %d: last meeting=%+v", turn, view.LastMeeting)
+		}
+	}
+	last, err := doc.ViewAt(doc.Result.Turns, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Status != GameStatusFinished || last.ChestScore != g.ChestScore {
+		t.Fatalf("last=%s chest=%d", last.Status, last.ChestScore)
+	}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import "fmt"
+
+// TimeoutAction is what the server does for a player whose turn timer expires.
+type TimeoutAction string
+
+const (
+	TimeoutActionPlayRandom TimeoutAction = "play_random" // play a random score card, or skip if none
+	TimeoutActionSkip       TimeoutAction = "skip"
+)
+
+// DeckExhaustion is what happens when a player must draw from an empty draw pile.
+type DeckExhaustion string
+
+const (
+	DeckExhaustionEnd       DeckExhaustion = "end"       // the game ends as if over was called
+	DeckExhaustionReshuffle DeckExhaustion = "reshuffle" // the discard pile becomes the draw pile, up to MaxReshuffles times
+	DeckExhaustionPlayOut   DeckExhaustion = "play_out"  // play continues without drawing until hands are empty
+)
+
+// MeetingOutcome is what happens to the player a meeting votes against.
+type MeetingOutcome string
+
+const (
+	MeetingOutcomeAccuse MeetingOutcome = "accuse" // one accusation, as if a card was played
+	MeetingOutcomeEject  MeetingOutcome = "eject"  // eliminated outright
+)
+
+// ImpostorBracket assigns Impostors impostors to games with at least MinPlayers players.
+type ImpostorBracket struct {
+	MinPlayers int `json:"minPlayers"`
+	Impostors  int `json:"impostors"`
+}
+
+// GameRules holds the tunable parameters of a game.
+//
+// A zero GameRules is not valid; start from DefaultRules and override fields.
+type GameRules struct {
+	MinPlayers int `json:"minPlayers"`
+	MaxPlayers int `json:"maxPlayers"`
+
+	StartingHandSize       int `json:"startingHandSize"`
+	AccusationsToEliminate int `json:"accusationsToEliminate"`
+
+	// GoalScore = players*GoalPerPlayer + GoalBonus.
+	GoalPerPlayer int `json:"goalPerPlayer"`
+	GoalBonus     int `json:"goalBonus"`
+
+	// ImpostorBrackets must be sorted by MinPlayers; the last matching bracket wins.
+	ImpostorBrackets []ImpostorBracket `json:"impostorBrackets"`
+
+	// ImpostorParityWins ends the game for the impostors once living impostors
+	// are at least as many as living good players, including when no good
+	// player is left.
+	ImpostorParityWins bool `json:"impostorParityWins"`
+
+	// TurnTimeoutSeconds limits each turn; 0 disables the turn timer.
+	TurnTimeoutSeconds int           `json:"turnTimeoutSeconds"`
+	TimeoutAction      TimeoutAction `json:"timeoutAction"`
+	// TimeoutsToEliminate eliminates a player after that many expired turns; 0 never does.
+	TimeoutsToEliminate int `json:"timeoutsToEliminate"`
+
+	DeckExhaustion DeckExhaustion `json:"deckExhaustion"` // empty means DeckExhaustionEnd
+	// MaxReshuffles limits DeckExhaustionReshuffle; the game ends once it is reached.
+	MaxReshuffles int `json:"maxReshuffles"`
+
+	// AllowDiscard lets a player spend their turn discarding a card face-down
+	// and drawing a replacement; AllowPass lets them end it without playing.
+	AllowDiscard bool `json:"allowDiscard"`
+	AllowPass    bool `json:"allowPass"`
+
+	// MeetingsPerPlayer is how many meetings each player may call; 0 disables meetings.
+	MeetingsPerPlayer  int            `json:"meetingsPerPlayer"`
+	MeetingOutcome     MeetingOutcome `json:"meetingOutcome"`
+	MeetingVoteSeconds int            `json:"meetingVoteSeconds"`
+}
+
+// DefaultRules returns the standard rule set.
+func DefaultRules() GameRules {
+	return GameRules{
+		MinPlayers:             MinPlayers,
+		MaxPlayers:             MaxPlayers,
+		StartingHandSize:       StartingHandSize,
+		AccusationsToEliminate: AccusationsToEliminate,
+		GoalPerPlayer:          1,
+		GoalBonus:              6,
+		ImpostorBrackets: []ImpostorBracket{
+			{MinPlayers: 0, Impostors: 1},
+			{MinPlayers: 6, Impostors: 2},
+		},
+		TimeoutAction:      TimeoutActionPlayRandom,
+		DeckExhaustion:     DeckExhaustionEnd,
+		MeetingOutcome:     MeetingOutcomeAccuse,
+		MeetingVoteSeconds: 60,
+	}
+}
+
+// GoalScore returns the chest score good players must reach.
+func (r GameRules) GoalScore(players int) int {
+	return players*r.GoalPerPlayer + r.GoalBonus
+}
+
+// ImpostorCount returns how many impostors a game of the given size has.
+func (r GameRules) ImpostorCount(players int) int {
+	n := 0
+	for _, b := range r.ImpostorBrackets {
+		if players >= b.MinPlayers {
+			n = b.Impostors
+		}
+	}
+	return n
+}
+
+// Validate reports whether the rules can produce a playable game.
+func (r GameRules) Validate() error {
+	if r.MinPlayers < 2 {
+		return fmt.Errorf("%w: minPlayers must be at least 2", ErrInvalidRules)
+	}
+	if r.MaxPlayers < r.MinPlayers {
+		return fmt.Errorf("%w: maxPlayers must be >= minPlayers", ErrInvalidRules)
+	}
+	if r.StartingHandSize < 1 {
+		return fmt.Errorf("%w: startingHandSize must be positive", ErrInvalidRules)
+	}
+	if r.AccusationsToEliminate < 1 {
+		return fmt.Errorf("%w: accusationsToEliminate must be positive", ErrInvalidRules)
+	}
+	if r.GoalPerPlayer < 0 {
+		return fmt.Errorf("%w: goalPerPlayer must not be negative", ErrInvalidRules)
+	}
+	if len(r.ImpostorBrackets) == 0 {
+		return fmt.Errorf("%w: impostorBrackets must not be empty", ErrInvalidRules)
+	}
+	for i := 1; i < len(r.ImpostorBrackets); i++ {
+		if r.ImpostorBrackets[i].MinPlayers <= r.ImpostorBrackets[i-1].MinPlayers {
+			return fmt.Errorf("%w: impostorBrackets must be sorted by minPlayers", ErrInvalidRules)
+		}
+	}
+	if r.TurnTimeoutSeconds < 0 || r.TimeoutsToEliminate < 0 {
+		return fmt.Errorf("%w: timeout settings must not be negative", ErrInvalidRules)
+	}
+	switch r.TimeoutAction {
+	case TimeoutActionPlayRandom, TimeoutActionSkip:
+	default:
+		if r.TurnTimeoutSeconds > 0 {
+			return fmt.Errorf("%w: unknown timeoutAction %q", ErrInvalidRules, r.TimeoutAction)
+		}
+	}
+	switch r.DeckExhaustion {
+	case "", DeckExhaustionEnd, DeckExhaustionPlayOut:
+	case DeckExhaustionReshuffle:
+		if r.MaxReshuffles < 1 {
+			return fmt.Errorf("%w: maxReshuffles must be positive to reshuffle", ErrInvalidRules)
+		}
+	default:
+		return fmt.Errorf("%w: unknown deckExhaustion %q", ErrInvalidRules, r.DeckExhaustion)
+	}
+	if r.MeetingsPerPlayer < 0 {
+		return fmt.Errorf("%w: meetingsPerPlayer must not be negative", ErrInvalidRules)
+	}
+	if r.MeetingsPerPlayer > 0 {
+		switch r.MeetingOutcome {
+		case MeetingOutcomeAccuse, MeetingOutcomeEject:
+		default:
+			return fmt.Errorf("%w: unknown meetingOutcome %q", ErrInvalidRules, r.MeetingOutcome)
+		}
+		if r.MeetingVoteSeconds < 1 {
+			return fmt.Errorf("%w: meetingVoteSeconds must be positive", ErrInvalidRules)
+		}
+	}
+	for n := r.MinPlayers; n <= r.MaxPlayers; n++ {
+		if r.GoalScore(n) <= 0 {
+			return fmt.Errorf("%w: goal score must be positive for %d players", ErrInvalidRules, n)
+		}
+		k := r.ImpostorCount(n)
+		if k < 1 || k >= n {
+			return fmt.Errorf("%w: %d impostors for %d players", ErrInvalidRules, k, n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultRulesValid(t *testing.T) {
+	if err := DefaultRules().Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRulesValidateRejectsBadImpostorCount(t *testing.T) {
+	r := DefaultRules()
+	r.ImpostorBrackets = []ImpostorBracket{{MinPlayers: 0, Impostors: 3}}
+	if err := r.Validate(); !errors.Is(err, ErrInvalidRules) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestRulesValidateRequiresReshuffleLimit(t *testing.T) {
+	r := DefaultRules()
+	r.DeckExhaustion = DeckExhaustionReshuffle
+	if err := r.Validate(); !errors.Is(err, ErrInvalidRules) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestCustomRulesApplied(t *testing.T) {
+	g := NewLobbyGame()
+	r := DefaultRules()
+	r.GoalBonus = 10
+	r.AccusationsToEliminate = 4
+	if err := g.SetRules(r); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := g.AddPlayer(&Player{ID: id, Name: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if g.GoalScore != 14 {
+		t.Fatalf("goal=%d", g.GoalScore)
+	}
+
+	a, _ := g.mustPlayer("a")
+	b, _ := g.mustPlayer("b")
+	for i := 0; i < 3; i++ {
+		a.Hand = append(a.Hand, Card{Type: CardTypeAccusation})
+		g.TurnIndex = 0
+		if err := g.PlayAccusationCard("a", len(a.Hand)-1, "b"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Eliminated {
+		t.Fatalf("eliminated after 3 accusations with threshold 4")
+	}
+}
+
+func TestSetRulesRejectedAfterStart(t *testing.T) {
+	g := NewLobbyGame()
+	g.Players = []*Player{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SetRules(DefaultRules()); err != ErrAlreadyInGame {
+		t.Fatalf("err=%v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "testing"
+
+func TestTurnTimeoutPlaysRandomScoreCard(t *testing.T) {
+	g := startSeeded(t, 3)
+	id := g.CurrentPlayerID()
+	p, _ := g.mustPlayer(id)
+	p.Hand = []Card{{Type: CardTypeAccusation}, {Type: CardTypeScore, Score: 1}}
+
+	if err := g.HandleTurnTimeout(id); err != nil {
+		t.Fatal(err)
+	}
+	if g.ChestScore != 1 || p.Timeouts != 1 {
+		t.Fatalf("chest=%d timeouts=%d", g.ChestScore, p.Timeouts)
+	}
+	if g.CurrentPlayerID() == id {
+		t.Fatalf("turn did not advance")
+	}
+}
+
+func TestTurnTimeoutEliminatesAfterLimit(t *testing.T) {
+	g := NewLobbyGame()
+	r := DefaultRules()
+	r.TimeoutAction = TimeoutActionSkip
+	r.TimeoutsToEliminate = 2
+	if err := g.SetRules(r); err != nil {
+		t.Fatal(err)
+	}
+	g.Players = []*Player{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	if err := g.SetSeed(11); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := g.mustPlayer("a")
+	for i := 0; i < 2 && g.Status == GameStatusInGame; i++ {
+		g.TurnIndex = 0
+		if err := g.HandleTurnTimeout("a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !a.Eliminated {
+		t.Fatalf("expected elimination after 2 timeouts")
+	}
+}
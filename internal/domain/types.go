@@ -14,9 +14,15 @@ type GameStatus string
 const (
 	GameStatusLobby    GameStatus = "lobby"
 	GameStatusInGame   GameStatus = "in_game"
+	GameStatusMeeting  GameStatus = "meeting" // in game, turns paused for a vote
 	GameStatusFinished GameStatus = "finished"
 )
 
+// Playing reports whether a game is under way, meetings included.
+func (s GameStatus) Playing() bool {
+	return s == GameStatusInGame || s == GameStatusMeeting
+}
+
 // Winner indicates who won when the game is finished.
 type Winner string
 
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // PublicPlayerView is safe to broadcast to all clients.
 type PublicPlayerView struct {
 	ID          string `json:"id"`
@@ -7,6 +9,15 @@ type PublicPlayerView struct {
 	Accusations int    `json:"accusations"`
 	Eliminated  bool   `json:"eliminated"`
 	HandCount   int    `json:"handCount"`
+	Timeouts    int    `json:"timeouts"`
+	Left        bool   `json:"left"`
+	// MeetingsLeft is how many meetings the player may still call.
+	MeetingsLeft int  `json:"meetingsLeft"`
+	Bot          bool `json:"bot,omitempty"` // set by the lobby
+
+	// Only set in omniscient (replay) views.
+	Role Role   `json:"role,omitempty"`
+	Hand []Card `json:"hand,omitempty"`
 }
 
 // SelfView contains private information for the requesting player only.
@@ -14,6 +25,40 @@ type SelfView struct {
 	ID   string `json:"id"`
 	Role Role   `json:"role"`
 	Hand []Card `json:"hand"`
+
+	Shields    int             `json:"shields"`
+	KnownRoles map[string]Role `json:"knownRoles,omitempty"` // learned with peek cards
+}
+
+// SummaryPlayer reveals a player's hidden state once the game is over.
+type SummaryPlayer struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Role            Role   `json:"role"`
+	Hand            []Card `json:"hand"`
+	Accusations     int    `json:"accusations"`     // received
+	AccusationsMade int    `json:"accusationsMade"` // accusation cards played
+	Eliminated      bool   `json:"eliminated"`
+}
+
+// CardPlay is a card played at a given point of the event log.
+type CardPlay struct {
+	Seq      int    `json:"seq"`
+	PlayerID string `json:"playerId"`
+	Card     Card   `json:"card"`
+}
+
+// GameSummary is the results screen payload, only set once the game is finished.
+type GameSummary struct {
+	Impostors     []string        `json:"impostors"`
+	Players       []SummaryPlayer `json:"players"`
+	NegativePlays []CardPlay      `json:"negativePlays"` // every negative score card, in play order
+}
+
+// RematchView is the pending rematch ready-vote.
+type RematchView struct {
+	StartImmediately bool     `json:"startImmediately"`
+	Ready            []string `json:"ready"` // player IDs that confirmed
 }
 
 // GameView is the per-player state payload.
@@ -22,16 +67,41 @@ type GameView struct {
 	Status GameStatus `json:"status"`
 	Winner Winner     `json:"winner"`
 
-	LobbyCode string `json:"lobbyCode"`
+	LobbyCode string    `json:"lobbyCode"`
+	HostID    string    `json:"hostId"` // set by the lobby
+	Rules     GameRules `json:"rules"`
+	DeckName  string    `json:"deckName"`
+
+	// Set by the lobby.
+	Spectators            int  `json:"spectators"`
+	SpectatorDelaySeconds int  `json:"spectatorDelaySeconds"`
+	GhostChat             bool `json:"ghostChat"`
 
 	ChestScore int `json:"chestScore"`
 	GoalScore  int `json:"goalScore"`
 
-	DrawCount int `json:"drawCount"`
+	DrawCount  int `json:"drawCount"`
+	Reshuffles int `json:"reshuffles"` // see GameRules.DeckExhaustion
+
+	// Seed is only revealed once the game is finished, so it can be attached to bug reports.
+	Seed uint64 `json:"seed,omitempty"`
+
+	CurrentTurnPlayerID string `json:"currentTurnPlayerId"`
+	// TurnDeadline is set by the lobby when the turn timer is running.
+	TurnDeadline *time.Time `json:"turnDeadline,omitempty"`
+
+	Meeting *MeetingView `json:"meeting,omitempty"` // open vote, status "meeting" only
+	// MeetingDeadline is set by the lobby while a meeting is open.
+	MeetingDeadline *time.Time     `json:"meetingDeadline,omitempty"`
+	LastMeeting     *MeetingResult `json:"lastMeeting,omitempty"`
+
+	Players []PublicPlayerView `json:"players"`
+	You     SelfView           `json:"you"`
+
+	Summary *GameSummary `json:"summary,omitempty"` // finished games only
 
-	CurrentTurnPlayerID string             `json:"currentTurnPlayerId"`
-	Players             []PublicPlayerView `json:"players"`
-	You                 SelfView           `json:"you"`
+	Rematch *RematchView `json:"rematch,omitempty"` // set by the lobby
+	Match   *MatchView   `json:"match,omitempty"`   // set by the lobby
 }
 
 func (g *Game) ViewFor(playerID string, lobbyCode string) (GameView, error) {
@@ -39,32 +109,115 @@ func (g *Game) ViewFor(playerID string, lobbyCode string) (GameView, error) {
 	if err != nil {
 		return GameView{}, err
 	}
+	view := g.publicView(lobbyCode)
+	view.You = SelfView{
+		ID:   p.ID,
+		Role: p.Role,
+		Hand: append([]Card(nil), p.Hand...),
+
+		Shields: p.Shields,
+	}
+	if len(p.KnownRoles) > 0 {
+		view.You.KnownRoles = make(map[string]Role, len(p.KnownRoles))
+		for id, role := range p.KnownRoles {
+			view.You.KnownRoles[id] = role
+		}
+	}
+	return view, nil
+}
+
+// SpectatorView shows public state only: no roles and no hands until the game
+// is finished.
+func (g *Game) SpectatorView(lobbyCode string) GameView {
+	return g.publicView(lobbyCode)
+}
+
+// OmniscientView reveals every player's role and hand. It is meant for
+// replays of finished games, never for live broadcast.
+func (g *Game) OmniscientView(lobbyCode string) GameView {
+	view := g.publicView(lobbyCode)
+	for i := range view.Players {
+		p, _ := g.mustPlayer(view.Players[i].ID)
+		view.Players[i].Role = p.Role
+		view.Players[i].Hand = append([]Card(nil), p.Hand...)
+	}
+	return view
+}
+
+func (g *Game) publicView(lobbyCode string) GameView {
 	view := GameView{
 		Status:              g.Status,
 		Winner:              g.Winner,
 		LobbyCode:           lobbyCode,
+		Rules:               g.Rules,
+		DeckName:            g.Deck.Name,
 		ChestScore:          g.ChestScore,
 		GoalScore:           g.GoalScore,
 		DrawCount:           len(g.DrawPile),
+		Reshuffles:          g.Reshuffles,
 		CurrentTurnPlayerID: g.CurrentPlayerID(),
 		Players:             make([]PublicPlayerView, 0, len(g.Players)),
-		You: SelfView{
-			ID:   p.ID,
-			Role: p.Role,
-			Hand: append([]Card(nil), p.Hand...),
-		},
+		Meeting:             g.meetingView(),
+		LastMeeting:         g.LastMeeting,
+	}
+	if g.Status == GameStatusFinished {
+		view.Seed = g.Seed
+		view.Summary = g.summary()
 	}
 	for _, other := range g.Players {
 		if other == nil {
 			continue
 		}
 		view.Players = append(view.Players, PublicPlayerView{
-			ID:          other.ID,
-			Name:        other.Name,
-			Accusations: other.Accusations,
-			Eliminated:  other.Eliminated,
-			HandCount:   len(other.Hand),
+			ID:           other.ID,
+			Name:         other.Name,
+			Accusations:  other.Accusations,
+			Eliminated:   other.Eliminated,
+			HandCount:    len(other.Hand),
+			Timeouts:     other.Timeouts,
+			Left:         other.Left,
+			MeetingsLeft: max(g.Rules.MeetingsPerPlayer-other.MeetingsCalled, 0),
 		})
 	}
-	return view, nil
+	return view
+}
+
+func (g *Game) summary() *GameSummary {
+	s := &GameSummary{
+		Impostors:     []string{},
+		Players:       make([]SummaryPlayer, 0, len(g.Players)),
+		NegativePlays: []CardPlay{},
+	}
+	made := make(map[string]int)
+	for _, e := range g.Events {
+		// Cards also reach the table when a timeout plays one; a discarded
+		// card never does.
+		if e.Card == nil || e.Type == EventDiscarded {
+			continue
+		}
+		switch {
+		case e.Card.IsAccusation():
+			made[e.PlayerID]++
+		case e.Card.IsScore() && e.Card.Score < 0:
+			s.NegativePlays = append(s.NegativePlays, CardPlay{Seq: e.Seq, PlayerID: e.PlayerID, Card: *e.Card})
+		}
+	}
+	for _, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		if p.Role == RoleImpostor {
+			s.Impostors = append(s.Impostors, p.ID)
+		}
+		s.Players = append(s.Players, SummaryPlayer{
+			ID:              p.ID,
+			Name:            p.Name,
+			Role:            p.Role,
+			Hand:            append([]Card{}, p.Hand...),
+			Accusations:     p.Accusations,
+			AccusationsMade: made[p.ID],
+			Eliminated:      p.Eliminated,
+		})
+	}
+	return s
 }
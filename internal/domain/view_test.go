@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestSummaryOnlyOnceFinished(t *testing.T) {
+	g := startSeeded(t, 41)
+	view, err := g.ViewFor("a", "X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Summary != nil {
+		t.Fatalf("summary leaked mid-game")
+	}
+
+	playOut(t, g)
+	view, _ = g.ViewFor("a", "X")
+	if view.Summary == nil {
+		t.Fatalf("no summary after game end")
+	}
+	if len(view.Summary.Impostors) != g.Rules.ImpostorCount(len(g.Players)) {
+		t.Fatalf("impostors=%v", view.Summary.Impostors)
+	}
+	negatives := 0
+	for _, e := range g.Events {
+		if e.Card != nil && e.Card.IsScore() && e.Card.Score < 0 {
+			negatives++
+		}
+	}
+	if len(view.Summary.NegativePlays) != negatives {
+		t.Fatalf("negative plays=%d want %d", len(view.Summary.NegativePlays), negatives)
+	}
+	for _, p := range view.Summary.Players {
+		if p.Role == "" {
+			t.Fatalf("role not revealed for %s", p.ID)
+		}
+	}
+}
+
+func TestSummaryCountsTimedOutPlays(t *testing.T) {
+	g := startSeeded(t, 3)
+	p, _ := giveCurrent(t, g, Card{Type: CardTypeScore, Score: -2})
+	if err := g.HandleTurnTimeout(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	timeout := g.Events[len(g.Events)-1]
+	if timeout.Type != EventTurnTimeout || timeout.Card == nil {
+		t.Fatalf("timeout did not play the card: %+v", timeout)
+	}
+
+	playOut(t, g)
+	view, _ := g.ViewFor("a", "X")
+	for _, play := range view.Summary.NegativePlays {
+		if play.Seq == timeout.Seq && play.PlayerID == p.ID {
+			return
+		}
+	}
+	t.Fatalf("timed-out play missing from %+v", view.Summary.NegativePlays)
+}
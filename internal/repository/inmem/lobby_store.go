@@ -39,3 +39,13 @@ func (s *LobbyStore) Delete(code string) {
 	defer s.mu.Unlock()
 	delete(s.lobbies, code)
 }
+
+func (s *LobbyStore) List() []*usecase.Lobby {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*usecase.Lobby, 0, len(s.lobbies))
+	for _, l := range s.lobbies {
+		out = append(out, l)
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package sim
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+var columns = []string{
+	"deck", "players", "games",
+	"good_win", "impostor_win", "no_winner", "stuck", "avg_turns", "deck_exhausted",
+	"good_elim", "impostor_elim",
+}
+
+func (r Row) fields() []string {
+	pct := func(f float64) string { return strconv.FormatFloat(100*f, 'f', 1, 64) }
+	avg := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+	return []string{
+		r.Deck, strconv.Itoa(r.Players), strconv.Itoa(r.Games),
+		pct(r.GoodWinRate()), pct(r.ImpostorWinRate()), pct(r.NoWinnerRate()), pct(r.StuckRate()),
+		avg(r.AvgTurns()), pct(r.ExhaustionRate()),
+		avg(r.AvgGoodEliminated()), avg(r.AvgImpostorEliminated()),
+	}
+}
+
+// WriteTable prints rows as an aligned text table. Rates are percentages;
+// eliminations are per-game averages.
+func WriteTable(w io.Writer, rows []Row) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	if err := writeTabbed(tw, columns); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := writeTabbed(tw, r.fields()); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func writeTabbed(w io.Writer, fields []string) error {
+	for _, f := range fields {
+		if _, err := fmt.Fprint(w, f, "\t"); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// WriteCSV prints rows as CSV with a header line, using the same units as WriteTable.
+func WriteCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.fields()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,193 @@
+// Package sim plays bot-only games in-process to measure game balance.
+package sim
+
+import (
+	"errors"
+	"fmt"
+
+	"game-server/internal/bot"
+	"game-server/internal/domain"
+)
+
+// maxSteps bounds a single game; a game still running after that many bot
+// moves means a strategy or rule combination cannot finish.
+const maxSteps = 10000
+
+// ErrGameStuck reports a game that cannot finish under the rules: nobody has
+// a legal move left, or it ran past maxSteps. Run counts such games in
+// Row.Stuck rather than failing.
+var ErrGameStuck = errors.New("simulated game did not finish")
+
+// Config describes a batch of simulations. Every combination of Players and
+// Decks is played Games times.
+type Config struct {
+	Games      int
+	Players    []int
+	Decks      []domain.DeckSpec
+	Rules      domain.GameRules
+	Difficulty bot.Difficulty
+	Seed       uint64 // base seed; game i of a batch uses Seed+i
+}
+
+// Row aggregates the games played with one player count and deck.
+type Row struct {
+	Players int
+	Deck    string
+	Games   int
+
+	GoodWins     int
+	ImpostorWins int
+	NoWinner     int // finished without a winner, e.g. everybody left
+	Stuck        int // could not finish; see ErrGameStuck
+
+	TotalTurns   int
+	DeckExhausts int // games in which the draw pile ran out
+
+	GoodEliminated     int
+	ImpostorEliminated int
+}
+
+func (r Row) GoodWinRate() float64     { return ratio(r.GoodWins, r.Games) }
+func (r Row) ImpostorWinRate() float64 { return ratio(r.ImpostorWins, r.Games) }
+func (r Row) NoWinnerRate() float64    { return ratio(r.NoWinner, r.Games) }
+func (r Row) StuckRate() float64       { return ratio(r.Stuck, r.Games) }
+
+// Game statistics below are averaged over the games that finished.
+
+func (r Row) AvgTurns() float64       { return ratio(r.TotalTurns, r.finished()) }
+func (r Row) ExhaustionRate() float64 { return ratio(r.DeckExhausts, r.finished()) }
+
+// AvgGoodEliminated is the mean number of good players eliminated per game.
+func (r Row) AvgGoodEliminated() float64 { return ratio(r.GoodEliminated, r.finished()) }
+
+// AvgImpostorEliminated is the mean number of impostors eliminated per game.
+func (r Row) AvgImpostorEliminated() float64 { return ratio(r.ImpostorEliminated, r.finished()) }
+
+func (r Row) finished() int { return r.Games - r.Stuck }
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// Run plays every batch in cfg and returns one row per player count and deck.
+func Run(cfg Config) ([]Row, error) {
+	var rows []Row
+	for _, deck := range cfg.Decks {
+		for _, n := range cfg.Players {
+			row := Row{Players: n, Deck: deck.Name}
+			for i := 0; i < cfg.Games; i++ {
+				g, err := Play(cfg.Rules, deck, n, cfg.Difficulty, cfg.Seed+uint64(i))
+				if errors.Is(err, ErrGameStuck) {
+					row.Games++
+					row.Stuck++
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("%s, %d players, game %d: %w", deck.Name, n, i, err)
+				}
+				row.add(g)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (r *Row) add(g *domain.Game) {
+	r.Games++
+	switch g.Winner {
+	case domain.WinnerGood:
+		r.GoodWins++
+	case domain.WinnerImpostor:
+		r.ImpostorWins++
+	default:
+		r.NoWinner++
+	}
+	r.TotalTurns += g.Turn
+	for _, e := range g.Events {
+		if e.DeckEmpty {
+			r.DeckExhausts++
+			break
+		}
+	}
+	for _, p := range g.Players {
+		switch {
+		case !p.Eliminated:
+		case p.Role == domain.RoleImpostor:
+			r.ImpostorEliminated++
+		default:
+			r.GoodEliminated++
+		}
+	}
+}
+
+// Play runs one bot-only game to the end and returns it.
+func Play(rules domain.GameRules, deck domain.DeckSpec, players int, d bot.Difficulty, seed uint64) (*domain.Game, error) {
+	g := domain.NewLobbyGame()
+	if err := g.SetRules(rules); err != nil {
+		return nil, err
+	}
+	if err := g.SetDeck(deck); err != nil {
+		return nil, err
+	}
+	brains := make([]*bot.Brain, players)
+	for i := range brains {
+		id := fmt.Sprintf("p%d", i+1)
+		if err := g.AddPlayer(&domain.Player{ID: id, Name: id}); err != nil {
+			return nil, err
+		}
+		b, err := bot.NewSeeded(d, seed*31+uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		brains[i] = b
+	}
+	if err := g.SetSeed(seed); err != nil {
+		return nil, err
+	}
+	if err := g.Start(); err != nil {
+		return nil, err
+	}
+
+	for step := 0; g.Status.Playing(); step++ {
+		if step >= maxSteps {
+			return nil, ErrGameStuck
+		}
+		if err := move(g, brains); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// move shows every bot the current state, like the lobby does, and plays
+// the first one that wants to act. An open meeting with nobody left to vote
+// is closed, as the lobby's vote timer would.
+func move(g *domain.Game, brains []*bot.Brain) error {
+	actor := -1
+	var action bot.Action
+	for i, b := range brains {
+		view, err := g.ViewFor(g.Players[i].ID, "")
+		if err != nil {
+			return err
+		}
+		b.Observe(view)
+		if a, ok := b.Next(view); ok && actor < 0 {
+			actor, action = i, a
+		}
+	}
+	if actor < 0 {
+		if g.Status == domain.GameStatusMeeting {
+			return g.CloseMeeting()
+		}
+		return ErrGameStuck
+	}
+	if err := bot.Apply(g, g.Players[actor].ID, action); err != nil {
+		// Not even the fallback move was legal.
+		return fmt.Errorf("%w: %v", ErrGameStuck, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package sim
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"game-server/internal/bot"
+	"game-server/internal/domain"
+)
+
+func TestRunIsReproducible(t *testing.T) {
+	cfg := Config{
+		Games:      20,
+		Players:    []int{3, 6},
+		Decks:      []domain.DeckSpec{domain.ClassicDeck(), domain.TricksDeck()},
+		Rules:      domain.DefaultRules(),
+		Difficulty: bot.Hard,
+		Seed:       7,
+	}
+	rows, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("rows=%d", len(rows))
+	}
+	for _, r := range rows {
+		if r.Games != 20 || r.GoodWins+r.ImpostorWins+r.NoWinner+r.Stuck != 20 {
+			t.Fatalf("row=%+v", r)
+		}
+	}
+	again, _ := Run(cfg)
+	if !reflect.DeepEqual(rows, again) {
+		t.Fatalf("same seed gave different results")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 5 {
+		t.Fatalf("csv lines=%d", lines)
+	}
+}
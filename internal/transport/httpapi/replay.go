@@ -0,0 +1,61 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"game-server/internal/domain"
+	"game-server/internal/usecase"
+)
+
+// ReplayHandler serves GET /replays/{code}: the finished game as a downloadable JSON document.
+func ReplayHandler(service *usecase.LobbyService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := r.PathValue("code")
+		doc, err := service.Replay(code)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Disposition", `attachment; filename="replay-`+code+`.json"`)
+		writeJSON(w, doc)
+	})
+}
+
+// ReplayTurnHandler serves GET /replays/{code}/turns/{turn}?player=ID: the game
+// state at the end of that turn, omniscient when no player is given.
+func ReplayTurnHandler(service *usecase.LobbyService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		turn, err := strconv.Atoi(r.PathValue("turn"))
+		if err != nil {
+			http.Error(w, "invalid turn", http.StatusBadRequest)
+			return
+		}
+		view, err := service.ReplayViewAt(r.PathValue("code"), turn, r.URL.Query().Get("player"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, view)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrLobbyNotFound), errors.Is(err, domain.ErrPlayerNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrGameNotFinished):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrTurnOutOfRange):
+		status = http.StatusBadRequest
+	}
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,123 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PatchOp is one JSON-Patch (RFC 6902) style change to a state document.
+// Only add, remove and replace are used.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// diffJSON returns the operations turning from into to. Both are decoded
+// JSON documents as produced by json.Unmarshal into an interface{}.
+func diffJSON(path string, from, to interface{}) []PatchOp {
+	switch a := from.(type) {
+	case map[string]interface{}:
+		b, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		var ops []PatchOp
+		for _, k := range sortedKeys(a) {
+			if _, ok := b[k]; !ok {
+				ops = append(ops, PatchOp{Op: "remove", Path: path + "/" + escapePointer(k)})
+			}
+		}
+		for _, k := range sortedKeys(b) {
+			p := path + "/" + escapePointer(k)
+			if old, ok := a[k]; ok {
+				ops = append(ops, diffJSON(p, old, b[k])...)
+			} else {
+				ops = append(ops, PatchOp{Op: "add", Path: p, Value: mustMarshal(b[k])})
+			}
+		}
+		return ops
+	case []interface{}:
+		b, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+		// Diff the common prefix, then trim or extend the tail. Removals go
+		// from the end so earlier indexes stay valid.
+		n := min(len(a), len(b))
+		var ops []PatchOp
+		for i := 0; i < n; i++ {
+			ops = append(ops, diffJSON(path+"/"+strconv.Itoa(i), a[i], b[i])...)
+		}
+		for i := len(a) - 1; i >= n; i-- {
+			ops = append(ops, PatchOp{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := n; i < len(b); i++ {
+			ops = append(ops, PatchOp{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: mustMarshal(b[i])})
+		}
+		return ops
+	default:
+		// from is a scalar, so comparing never panics.
+		if from == to {
+			return nil
+		}
+	}
+	return []PatchOp{{Op: "replace", Path: path, Value: mustMarshal(to)}}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a key for use in a JSON Pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	raw, _ := json.Marshal(v) // v came out of json.Unmarshal
+	return raw
+}
+
+// pushState brings the client up to date with the lobby: a delta against the
+// last state it was sent, or a full snapshot for new connections and when
+// full is set. Views older than the last one sent are dropped.
+func (s *Server) pushState(ctx context.Context, cc *clientConn, full bool) error {
+	view, version, err := s.service.VersionedView(cc.lobbyCode, cc.playerID)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(view)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+
+	cc.viewMu.Lock()
+	defer cc.viewMu.Unlock()
+	if cc.view == nil || full {
+		cc.view, cc.version = doc, version
+		return cc.send(ctx, ServerMessage{Type: "state", Version: version, State: json.RawMessage(raw)})
+	}
+	if version <= cc.version {
+		return nil
+	}
+	ops := diffJSON("", cc.view, doc)
+	if len(ops) == 0 {
+		return nil
+	}
+	base := cc.version
+	cc.view, cc.version = doc, version
+	return cc.send(ctx, ServerMessage{Type: "delta", Version: version, BaseVersion: base, Ops: ops})
+}
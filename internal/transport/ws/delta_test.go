@@ -0,0 +1,92 @@
+package ws
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// applyOps is a minimal client-side patcher for the ops diffJSON emits.
+func applyOps(t *testing.T, doc interface{}, ops []PatchOp) interface{} {
+	t.Helper()
+	for _, op := range ops {
+		var value interface{}
+		if len(op.Value) > 0 {
+			value = decode(t, string(op.Value))
+		}
+		if op.Path == "" {
+			doc = value
+			continue
+		}
+		parts := strings.Split(op.Path[1:], "/")
+		doc = applyAt(t, doc, parts, op.Op, value)
+	}
+	return doc
+}
+
+func applyAt(t *testing.T, node interface{}, parts []string, op string, value interface{}) interface{} {
+	key := strings.NewReplacer("~1", "/", "~0", "~").Replace(parts[0])
+	switch n := node.(type) {
+	case map[string]interface{}:
+		switch {
+		case len(parts) > 1:
+			n[key] = applyAt(t, n[key], parts[1:], op, value)
+		case op == "remove":
+			delete(n, key)
+		default:
+			n[key] = value
+		}
+		return n
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			t.Fatalf("bad index %q", key)
+		}
+		switch {
+		case len(parts) > 1:
+			n[i] = applyAt(t, n[i], parts[1:], op, value)
+		case op == "remove":
+			n = append(n[:i], n[i+1:]...)
+		case op == "add":
+			n = append(n[:i], append([]interface{}{value}, n[i:]...)...)
+		default:
+			n[i] = value
+		}
+		return n
+	}
+	t.Fatalf("cannot apply %s below a scalar", op)
+	return nil
+}
+
+func TestDiffJSONRoundTrips(t *testing.T) {
+	cases := []struct{ from, to string }{
+		{`{"a":1,"b":"x"}`, `{"a":1,"b":"x"}`},
+		{`{"a":1,"b":"x"}`, `{"a":2,"c":false}`},
+		{`{"players":[{"id":"a","n":1},{"id":"b","n":2}]}`, `{"players":[{"id":"a","n":3}]}`},
+		{`{"hand":[1,2]}`, `{"hand":[1,2,3,4]}`},
+		{`{"m":{"x/y":1,"t~":2}}`, `{"m":{"x/y":null}}`},
+		{`{"deadline":"t1"}`, `{"deadline":{"at":"t2"}}`},
+		{`[1]`, `{"a":1}`},
+	}
+	for _, c := range cases {
+		ops := diffJSON("", decode(t, c.from), decode(t, c.to))
+		got := applyOps(t, decode(t, c.from), ops)
+		if !reflect.DeepEqual(got, decode(t, c.to)) {
+			t.Errorf("%s -> %s: ops %+v gave %v", c.from, c.to, ops, got)
+		}
+		if c.from == c.to && len(ops) != 0 {
+			t.Errorf("%s: ops for an unchanged document: %+v", c.from, ops)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package ws
+
+import (
+	"errors"
+	"net/http"
+
+	"game-server/internal/bot"
+	"game-server/internal/domain"
+	"game-server/internal/usecase"
+)
+
+var errUnknownMessageType = errors.New("unknown message type")
+
+// ErrorCode is a stable, machine-readable error identifier. Clients should
+// branch on it rather than on the human-readable message.
+type ErrorCode string
+
+// ErrorCategory groups error codes the way HTTP status classes do.
+type ErrorCategory string
+
+const (
+	CategoryBadRequest  ErrorCategory = "bad_request"  // the message itself is wrong
+	CategoryForbidden   ErrorCategory = "forbidden"    // not allowed for this player
+	CategoryNotFound    ErrorCategory = "not_found"    // lobby, player or deck does not exist
+	CategoryConflict    ErrorCategory = "conflict"     // not allowed in the current game state
+	CategoryRateLimited ErrorCategory = "rate_limited" // retry later
+	CategoryInternal    ErrorCategory = "internal"     // server bug; report it
+)
+
+var categoryStatus = map[ErrorCategory]int{
+	CategoryBadRequest:  http.StatusBadRequest,
+	CategoryForbidden:   http.StatusForbidden,
+	CategoryNotFound:    http.StatusNotFound,
+	CategoryConflict:    http.StatusConflict,
+	CategoryRateLimited: http.StatusTooManyRequests,
+	CategoryInternal:    http.StatusInternalServerError,
+}
+
+// ErrorPayload is the machine-readable part of an "error" message.
+type ErrorPayload struct {
+	Code     ErrorCode     `json:"code"`
+	Category ErrorCategory `json:"category"`
+	Status   int           `json:"status"` // HTTP status matching Category
+	Hint     string        `json:"hint"`   // short explanation in the client's language
+}
+
+// Error codes sent to clients. They are part of the protocol: never change
+// the value of an existing code.
+const (
+	CodeInternal ErrorCode = "internal"
+
+	// Protocol.
+	CodeInvalidHandshake   ErrorCode = "invalid_handshake"
+	CodeUnknownMessageType ErrorCode = "unknown_message_type"
+	CodeReadOnly           ErrorCode = "read_only"
+	CodeUnknownDifficulty  ErrorCode = "unknown_difficulty"
+
+	// Lobby.
+	CodeLobbyNotFound         ErrorCode = "lobby_not_found"
+	CodeLobbyCodeCollision    ErrorCode = "lobby_code_collision"
+	CodeNotInLobby            ErrorCode = "not_in_lobby"
+	CodeLobbyAlreadyStarted   ErrorCode = "lobby_already_started"
+	CodeDeckNotFound          ErrorCode = "deck_not_found"
+	CodeInvalidResumeToken    ErrorCode = "invalid_resume_token"
+	CodeNotHost               ErrorCode = "not_host"
+	CodeNoRematchVote         ErrorCode = "no_rematch_vote"
+	CodeInvalidSpectatorDelay ErrorCode = "invalid_spectator_delay"
+	CodeChatEmpty             ErrorCode = "chat_empty"
+	CodeChatTooLong           ErrorCode = "chat_too_long"
+	CodeChatRateLimited       ErrorCode = "chat_rate_limited"
+
+	// Game.
+	CodeInvalidState       ErrorCode = "invalid_state"
+	CodePlayerNotFound     ErrorCode = "player_not_found"
+	CodeNotPlayersTurn     ErrorCode = "not_players_turn"
+	CodeInvalidHandIndex   ErrorCode = "invalid_hand_index"
+	CodeInvalidCardType    ErrorCode = "invalid_card_type"
+	CodeTargetNotFound     ErrorCode = "target_not_found"
+	CodeInvalidTarget      ErrorCode = "invalid_target"
+	CodeOnlyGoodCanCall    ErrorCode = "only_good_can_call"
+	CodeCannotStart        ErrorCode = "cannot_start"
+	CodeGameFinished       ErrorCode = "game_finished"
+	CodePlayerEliminated   ErrorCode = "player_eliminated"
+	CodeNotEnoughPlayers   ErrorCode = "not_enough_players"
+	CodeLobbyFull          ErrorCode = "lobby_full"
+	CodeGameAlreadyStarted ErrorCode = "game_already_started"
+	CodeDeckEmpty          ErrorCode = "deck_empty"
+	CodeDuplicatePlayer    ErrorCode = "duplicate_player"
+	CodeInvalidRules       ErrorCode = "invalid_rules"
+	CodeInvalidDeck        ErrorCode = "invalid_deck"
+	CodeDeckTooSmall       ErrorCode = "deck_too_small"
+	CodeReplayMismatch     ErrorCode = "replay_mismatch"
+	CodeGameNotFinished    ErrorCode = "game_not_finished"
+	CodeTurnOutOfRange     ErrorCode = "turn_out_of_range"
+	CodeInvalidMatch       ErrorCode = "invalid_match"
+	CodeMatchFinished      ErrorCode = "match_finished"
+	CodeNotImpostor        ErrorCode = "not_impostor"
+	CodeMeetingInProgress  ErrorCode = "meeting_in_progress"
+	CodeNoMeeting          ErrorCode = "no_meeting"
+	CodeNoMeetingsLeft     ErrorCode = "no_meetings_left"
+	CodeAlreadyVoted       ErrorCode = "already_voted"
+	CodeActionDisabled     ErrorCode = "action_disabled"
+)
+
+type errorMapping struct {
+	err      error
+	code     ErrorCode
+	category ErrorCategory
+}
+
+// errorCodes maps every sentinel error a client can trigger. It is matched
+// with errors.Is in order, so wrapped errors resolve to their sentinel.
+var errorCodes = []errorMapping{
+	// Protocol.
+	{errInvalidHandshake, CodeInvalidHandshake, CategoryBadRequest},
+	{errUnknownMessageType, CodeUnknownMessageType, CategoryBadRequest},
+	{errReadOnly, CodeReadOnly, CategoryForbidden},
+	{bot.ErrUnknownDifficulty, CodeUnknownDifficulty, CategoryBadRequest},
+
+	// Lobby.
+	{usecase.ErrLobbyNotFound, CodeLobbyNotFound, CategoryNotFound},
+	{usecase.ErrLobbyCodeCollision, CodeLobbyCodeCollision, CategoryInternal},
+	{usecase.ErrPlayerNotInLobby, CodeNotInLobby, CategoryForbidden},
+	{usecase.ErrLobbyAlreadyStarted, CodeLobbyAlreadyStarted, CategoryConflict},
+	{usecase.ErrDeckNotFound, CodeDeckNotFound, CategoryNotFound},
+	{usecase.ErrInvalidResumeToken, CodeInvalidResumeToken, CategoryForbidden},
+	{usecase.ErrNotHost, CodeNotHost, CategoryForbidden},
+	{usecase.ErrNoRematchVote, CodeNoRematchVote, CategoryConflict},
+	{usecase.ErrInvalidSpectatorDelay, CodeInvalidSpectatorDelay, CategoryBadRequest},
+	{usecase.ErrChatEmpty, CodeChatEmpty, CategoryBadRequest},
+	{usecase.ErrChatTooLong, CodeChatTooLong, CategoryBadRequest},
+	{usecase.ErrChatRateLimited, CodeChatRateLimited, CategoryRateLimited},
+
+	// Game.
+	{domain.ErrInvalidState, CodeInvalidState, CategoryConflict},
+	{domain.ErrPlayerNotFound, CodePlayerNotFound, CategoryNotFound},
+	{domain.ErrNotPlayersTurn, CodeNotPlayersTurn, CategoryConflict},
+	{domain.ErrInvalidHandIndex, CodeInvalidHandIndex, CategoryBadRequest},
+	{domain.ErrInvalidCardType, CodeInvalidCardType, CategoryBadRequest},
+	{domain.ErrTargetNotFound, CodeTargetNotFound, CategoryNotFound},
+	{domain.ErrTargetInvalid, CodeInvalidTarget, CategoryBadRequest},
+	{domain.ErrOnlyGoodCanCall, CodeOnlyGoodCanCall, CategoryForbidden},
+	{domain.ErrCannotStart, CodeCannotStart, CategoryConflict},
+	{domain.ErrGameFinished, CodeGameFinished, CategoryConflict},
+	{domain.ErrPlayerEliminated, CodePlayerEliminated, CategoryForbidden},
+	{domain.ErrNotEnoughPlayers, CodeNotEnoughPlayers, CategoryConflict},
+	{domain.ErrTooManyPlayers, CodeLobbyFull, CategoryConflict},
+	{domain.ErrAlreadyInGame, CodeGameAlreadyStarted, CategoryConflict},
+	{domain.ErrDeckEmpty, CodeDeckEmpty, CategoryConflict},
+	{domain.ErrDuplicatePlayerID, CodeDuplicatePlayer, CategoryConflict},
+	{domain.ErrInvalidRules, CodeInvalidRules, CategoryBadRequest},
+	{domain.ErrInvalidDeck, CodeInvalidDeck, CategoryBadRequest},
+	{domain.ErrDeckTooSmall, CodeDeckTooSmall, CategoryBadRequest},
+	{domain.ErrReplayMismatch, CodeReplayMismatch, CategoryInternal},
+	{domain.ErrGameNotFinished, CodeGameNotFinished, CategoryConflict},
+	{domain.ErrTurnOutOfRange, CodeTurnOutOfRange, CategoryBadRequest},
+	{domain.ErrInvalidMatch, CodeInvalidMatch, CategoryBadRequest},
+	{domain.ErrMatchFinished, CodeMatchFinished, CategoryConflict},
+	{domain.ErrNotImpostor, CodeNotImpostor, CategoryForbidden},
+	{domain.ErrMeetingInProgress, CodeMeetingInProgress, CategoryConflict},
+	{domain.ErrNoMeeting, CodeNoMeeting, CategoryConflict},
+	{domain.ErrNoMeetingsLeft, CodeNoMeetingsLeft, CategoryForbidden},
+	{domain.ErrAlreadyVoted, CodeAlreadyVoted, CategoryConflict},
+	{domain.ErrActionDisabled, CodeActionDisabled, CategoryForbidden},
+}
+
+// errorPayload classifies err; unknown errors are reported as internal.
+func errorPayload(err error, lang string) *ErrorPayload {
+	code, category := CodeInternal, CategoryInternal
+	for _, m := range errorCodes {
+		if errors.Is(err, m.err) {
+			code, category = m.code, m.category
+			break
+		}
+	}
+	return &ErrorPayload{
+		Code:     code,
+		Category: category,
+		Status:   categoryStatus[category],
+		Hint:     hint(code, lang),
+	}
+}
+
+// errorMessage builds the "error" message for err in reply to requestID.
+// Message keeps the full error text for logs and older clients.
+func errorMessage(err error, requestID, lang string) ServerMessage {
+	return ServerMessage{
+		Type:      "error",
+		Message:   err.Error(),
+		RequestID: requestID,
+		Error:     errorPayload(err, lang),
+	}
+}
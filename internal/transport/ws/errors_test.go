@@ -0,0 +1,160 @@
+package ws
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+
+	"game-server/internal/bot"
+	"game-server/internal/domain"
+	"game-server/internal/usecase"
+)
+
+// sentinels lists every error a client can be sent, by name.
+// TestSentinelListIsComplete keeps it in sync with the errors.go files.
+var sentinels = map[string]error{
+	"errInvalidHandshake":              errInvalidHandshake,
+	"errUnknownMessageType":            errUnknownMessageType,
+	"errReadOnly":                      errReadOnly,
+	"bot.ErrUnknownDifficulty":         bot.ErrUnknownDifficulty,
+	"usecase.ErrLobbyNotFound":         usecase.ErrLobbyNotFound,
+	"usecase.ErrLobbyCodeCollision":    usecase.ErrLobbyCodeCollision,
+	"usecase.ErrPlayerNotInLobby":      usecase.ErrPlayerNotInLobby,
+	"usecase.ErrLobbyAlreadyStarted":   usecase.ErrLobbyAlreadyStarted,
+	"usecase.ErrDeckNotFound":          usecase.ErrDeckNotFound,
+	"usecase.ErrInvalidResumeToken":    usecase.ErrInvalidResumeToken,
+	"usecase.ErrNotHost":               usecase.ErrNotHost,
+	"usecase.ErrNoRematchVote":         usecase.ErrNoRematchVote,
+	"usecase.ErrInvalidSpectatorDelay": usecase.ErrInvalidSpectatorDelay,
+	"usecase.ErrChatEmpty":             usecase.ErrChatEmpty,
+	"usecase.ErrChatTooLong":           usecase.ErrChatTooLong,
+	"usecase.ErrChatRateLimited":       usecase.ErrChatRateLimited,
+	"domain.ErrInvalidState":           domain.ErrInvalidState,
+	"domain.ErrPlayerNotFound":         domain.ErrPlayerNotFound,
+	"domain.ErrNotPlayersTurn":         domain.ErrNotPlayersTurn,
+	"domain.ErrInvalidHandIndex":       domain.ErrInvalidHandIndex,
+	"domain.ErrInvalidCardType":        domain.ErrInvalidCardType,
+	"domain.ErrTargetNotFound":         domain.ErrTargetNotFound,
+	"domain.ErrTargetInvalid":          domain.ErrTargetInvalid,
+	"domain.ErrOnlyGoodCanCall":        domain.ErrOnlyGoodCanCall,
+	"domain.ErrCannotStart":            domain.ErrCannotStart,
+	"domain.ErrGameFinished":           domain.ErrGameFinished,
+	"domain.ErrPlayerEliminated":       domain.ErrPlayerEliminated,
+	"domain.ErrNotEnoughPlayers":       domain.ErrNotEnoughPlayers,
+	"domain.ErrTooManyPlayers":         domain.ErrTooManyPlayers,
+	"domain.ErrAlreadyInGame":          domain.ErrAlreadyInGame,
+	"domain.ErrDeckEmpty":              domain.ErrDeckEmpty,
+	"domain.ErrDuplicatePlayerID":      domain.ErrDuplicatePlayerID,
+	"domain.ErrInvalidRules":           domain.ErrInvalidRules,
+	"domain.ErrInvalidDeck":            domain.ErrInvalidDeck,
+	"domain.ErrDeckTooSmall":           domain.ErrDeckTooSmall,
+	"domain.ErrReplayMismatch":         domain.ErrReplayMismatch,
+	"domain.ErrGameNotFinished":        domain.ErrGameNotFinished,
+	"domain.ErrTurnOutOfRange":         domain.ErrTurnOutOfRange,
+	"domain.ErrInvalidMatch":           domain.ErrInvalidMatch,
+	"domain.ErrMatchFinished":          domain.ErrMatchFinished,
+	"domain.ErrNotImpostor":            domain.ErrNotImpostor,
+	"domain.ErrMeetingInProgress":      domain.ErrMeetingInProgress,
+	"domain.ErrNoMeeting":              domain.ErrNoMeeting,
+	"domain.ErrNoMeetingsLeft":         domain.ErrNoMeetingsLeft,
+	"domain.ErrAlreadyVoted":           domain.ErrAlreadyVoted,
+	"domain.ErrActionDisabled":         domain.ErrActionDisabled,
+}
+
+// TestSentinelListIsComplete fails when a package declares a new exported
+// sentinel that sentinels, and so probably errorCodes, does not know about.
+func TestSentinelListIsComplete(t *testing.T) {
+	for pkg, file := range map[string]string{
+		"domain":  "../../domain/errors.go",
+		"usecase": "../../usecase/errors.go",
+		"bot":     "../../bot/bot.go",
+	} {
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					if strings.HasPrefix(name.Name, "Err") {
+						if _, ok := sentinels[pkg+"."+name.Name]; !ok {
+							t.Errorf("%s.%s is missing from sentinels", pkg, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestEverySentinelHasACode(t *testing.T) {
+	seen := make(map[ErrorCode]error)
+	for _, err := range sentinels {
+		for _, e := range []error{err, fmt.Errorf("%w: detail", err)} {
+			p := errorPayload(e, "")
+			if p.Code == CodeInternal || p.Code == "" {
+				t.Errorf("%q maps to %q", e, p.Code)
+			}
+			if p.Status != categoryStatus[p.Category] || p.Status == 0 {
+				t.Errorf("%q: category %q status %d", e, p.Category, p.Status)
+			}
+		}
+		code := errorPayload(err, "").Code
+		if other, dup := seen[code]; dup {
+			t.Errorf("%q and %q share code %q", err, other, code)
+		}
+		seen[code] = err
+	}
+	if len(seen) != len(errorCodes) {
+		t.Errorf("sentinels cover %d of %d codes; keep the list in sync", len(seen), len(errorCodes))
+	}
+
+	p := errorPayload(errors.New("boom"), "")
+	if p.Code != CodeInternal || p.Status != http.StatusInternalServerError {
+		t.Errorf("unknown error: %+v", p)
+	}
+}
+
+func TestEveryCodeHasHints(t *testing.T) {
+	codes := []ErrorCode{CodeInternal}
+	for _, m := range errorCodes {
+		codes = append(codes, m.code)
+	}
+	for _, lang := range []string{"en", "fr"} {
+		for _, code := range codes {
+			if hints[lang][code] == "" {
+				t.Errorf("%s: no hint for %q", lang, code)
+			}
+		}
+	}
+}
+
+func TestNormalizeLang(t *testing.T) {
+	for tag, want := range map[string]string{
+		"fr": "fr", "fr-FR": "fr", "FR_ca": "fr", "en-US": "en", "de": "en", "": "en",
+	} {
+		if got := normalizeLang(tag); got != want {
+			t.Errorf("normalizeLang(%q)=%q, want %q", tag, got, want)
+		}
+	}
+	if got := hint("not_players_turn", "fr-FR"); got != hints["fr"]["not_players_turn"] {
+		t.Errorf("fr hint=%q", got)
+	}
+}
+
+func TestErrorMessageEchoesRequestID(t *testing.T) {
+	msg := errorMessage(fmt.Errorf("%w: maxPlayers", domain.ErrInvalidRules), "r1", "fr")
+	if msg.Type != "error" || msg.RequestID != "r1" || msg.Error.Code != CodeInvalidRules ||
+		msg.Error.Category != CategoryBadRequest || msg.Error.Hint != hints["fr"]["invalid_rules"] {
+		t.Fatalf("msg=%+v payload=%+v", msg, msg.Error)
+	}
+}
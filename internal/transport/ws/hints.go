@@ -0,0 +1,128 @@
+package ws
+
+import "strings"
+
+// defaultLang is used for hints when the client's language is unknown.
+const defaultLang = "en"
+
+// hints holds the human-readable explanation of each error code per language.
+var hints = map[string]map[ErrorCode]string{
+	"en": {
+		CodeInternal:              "Something went wrong on the server.",
+		CodeInvalidHandshake:      "Create, join, resume or spectate a lobby first.",
+		CodeUnknownMessageType:    "The server does not understand this message.",
+		CodeReadOnly:              "Spectators cannot act.",
+		CodeUnknownDifficulty:     "Pick easy, normal or hard.",
+		CodeLobbyNotFound:         "This lobby does not exist.",
+		CodeLobbyCodeCollision:    "Could not create a lobby, please try again.",
+		CodeNotInLobby:            "You are not in this lobby.",
+		CodeLobbyAlreadyStarted:   "The game has already started.",
+		CodeDeckNotFound:          "This deck does not exist.",
+		CodeInvalidResumeToken:    "Your session expired; join the lobby again.",
+		CodeNotHost:               "Only the host can do that.",
+		CodeNoRematchVote:         "No rematch has been proposed.",
+		CodeInvalidSpectatorDelay: "The spectator delay is out of range.",
+		CodeChatEmpty:             "Write something first.",
+		CodeChatTooLong:           "Your message is too long.",
+		CodeChatRateLimited:       "Slow down before sending more messages.",
+		CodeInvalidState:          "You cannot do that right now.",
+		CodePlayerNotFound:        "This player is not in the game.",
+		CodeNotPlayersTurn:        "It is not your turn.",
+		CodeInvalidHandIndex:      "That card is not in your hand.",
+		CodeInvalidCardType:       "That card cannot be played this way.",
+		CodeTargetNotFound:        "That player is not in the game.",
+		CodeInvalidTarget:         "You cannot target that player.",
+		CodeOnlyGoodCanCall:       "Only good players can call over.",
+		CodeCannotStart:           "The game cannot start yet.",
+		CodeGameFinished:          "The game is over.",
+		CodePlayerEliminated:      "You have been eliminated.",
+		CodeNotEnoughPlayers:      "Not enough players to start.",
+		CodeLobbyFull:             "The lobby is full.",
+		CodeGameAlreadyStarted:    "The game has already started.",
+		CodeDeckEmpty:             "The draw pile is empty.",
+		CodeDuplicatePlayer:       "This player is already seated.",
+		CodeInvalidRules:          "These rules are not valid.",
+		CodeInvalidDeck:           "This deck is not valid.",
+		CodeDeckTooSmall:          "This deck is too small for the table.",
+		CodeReplayMismatch:        "The replay does not match the game.",
+		CodeGameNotFinished:       "The game is not over yet.",
+		CodeTurnOutOfRange:        "That turn does not exist.",
+		CodeInvalidMatch:          "These match settings are not valid.",
+		CodeMatchFinished:         "The match is over.",
+		CodeNotImpostor:           "Only impostors can use this channel.",
+		CodeMeetingInProgress:     "Wait for the meeting to end.",
+		CodeNoMeeting:             "There is no meeting to vote in.",
+		CodeNoMeetingsLeft:        "You cannot call another meeting.",
+		CodeAlreadyVoted:          "You have already voted.",
+		CodeActionDisabled:        "The rules do not allow that.",
+	},
+	"fr": {
+		CodeInternal:              "Une erreur est survenue sur le serveur.",
+		CodeInvalidHandshake:      "Créez, rejoignez, reprenez ou observez d'abord un salon.",
+		CodeUnknownMessageType:    "Le serveur ne comprend pas ce message.",
+		CodeReadOnly:              "Les spectateurs ne peuvent pas jouer.",
+		CodeUnknownDifficulty:     "Choisissez facile (easy), normal ou difficile (hard).",
+		CodeLobbyNotFound:         "Ce salon n'existe pas.",
+		CodeLobbyCodeCollision:    "Impossible de créer le salon, réessayez.",
+		CodeNotInLobby:            "Vous n'êtes pas dans ce salon.",
+		CodeLobbyAlreadyStarted:   "La partie a déjà commencé.",
+		CodeDeckNotFound:          "Ce paquet n'existe pas.",
+		CodeInvalidResumeToken:    "Votre session a expiré ; rejoignez à nouveau le salon.",
+		CodeNotHost:               "Seul l'hôte peut faire cela.",
+		CodeNoRematchVote:         "Aucune revanche n'a été proposée.",
+		CodeInvalidSpectatorDelay: "Le délai des spectateurs est hors limites.",
+		CodeChatEmpty:             "Écrivez d'abord quelque chose.",
+		CodeChatTooLong:           "Votre message est trop long.",
+		CodeChatRateLimited:       "Patientez avant d'envoyer d'autres messages.",
+		CodeInvalidState:          "Impossible pour le moment.",
+		CodePlayerNotFound:        "Ce joueur n'est pas dans la partie.",
+		CodeNotPlayersTurn:        "Ce n'est pas votre tour.",
+		CodeInvalidHandIndex:      "Cette carte n'est pas dans votre main.",
+		CodeInvalidCardType:       "Cette carte ne se joue pas ainsi.",
+		CodeTargetNotFound:        "Ce joueur n'est pas dans la partie.",
+		CodeInvalidTarget:         "Vous ne pouvez pas cibler ce joueur.",
+		CodeOnlyGoodCanCall:       "Seuls les joueurs honnêtes peuvent annoncer la fin.",
+		CodeCannotStart:           "La partie ne peut pas encore commencer.",
+		CodeGameFinished:          "La partie est terminée.",
+		CodePlayerEliminated:      "Vous avez été éliminé.",
+		CodeNotEnoughPlayers:      "Pas assez de joueurs pour commencer.",
+		CodeLobbyFull:             "Le salon est complet.",
+		CodeGameAlreadyStarted:    "La partie a déjà commencé.",
+		CodeDeckEmpty:             "La pioche est vide.",
+		CodeDuplicatePlayer:       "Ce joueur est déjà assis.",
+		CodeInvalidRules:          "Ces règles ne sont pas valides.",
+		CodeInvalidDeck:           "Ce paquet n'est pas valide.",
+		CodeDeckTooSmall:          "Ce paquet est trop petit pour la table.",
+		CodeReplayMismatch:        "Le replay ne correspond pas à la partie.",
+		CodeGameNotFinished:       "La partie n'est pas encore terminée.",
+		CodeTurnOutOfRange:        "Ce tour n'existe pas.",
+		CodeInvalidMatch:          "Ces réglages de match ne sont pas valides.",
+		CodeMatchFinished:         "Le match est terminé.",
+		CodeNotImpostor:           "Seuls les imposteurs peuvent utiliser ce canal.",
+		CodeMeetingInProgress:     "Attendez la fin de la réunion.",
+		CodeNoMeeting:             "Aucune réunion en cours.",
+		CodeNoMeetingsLeft:        "Vous ne pouvez plus convoquer de réunion.",
+		CodeAlreadyVoted:          "Vous avez déjà voté.",
+		CodeActionDisabled:        "Les règles ne le permettent pas.",
+	},
+}
+
+// normalizeLang reduces a language tag such as "fr-FR" to a supported
+// language, falling back to defaultLang.
+func normalizeLang(tag string) string {
+	lang := strings.ToLower(tag)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := hints[lang]; ok {
+		return lang
+	}
+	return defaultLang
+}
+
+func hint(code ErrorCode, lang string) string {
+	if h, ok := hints[normalizeLang(lang)][code]; ok {
+		return h
+	}
+	return hints[defaultLang][code]
+}
@@ -1,12 +1,35 @@
 package ws
 
+import (
+	"encoding/json"
+
+	"game-server/internal/domain"
+	"game-server/internal/usecase"
+)
+
 // ClientMessage is any message coming from Unity/client.
 type ClientMessage struct {
 	Type      string `json:"type"`
+	RequestID string `json:"requestId,omitempty"` // answered with ack or error; retries with the same ID are applied once
+	Lang      string `json:"lang,omitempty"`      // handshake only: language of error hints, e.g. "fr"
 	Name      string `json:"name,omitempty"`
 	Code      string `json:"code,omitempty"`
+	Token     string `json:"token,omitempty"` // resume
 	HandIndex int    `json:"handIndex,omitempty"`
 	TargetID  string `json:"targetId,omitempty"`
+	Start     bool   `json:"start,omitempty"` // rematch: start the new game once everyone is ready
+	Text      string `json:"text,omitempty"`  // chat
+
+	Difficulty string `json:"difficulty,omitempty"` // add_bot: easy, normal or hard
+
+	// update_settings
+	Rules    *domain.GameRules   `json:"rules,omitempty"`
+	Match    *domain.MatchConfig `json:"match,omitempty"` // rounds=0 switches back to single games
+	DeckName string              `json:"deckName,omitempty"`
+	Deck     json.RawMessage     `json:"deck,omitempty"` // custom domain.DeckSpec
+
+	SpectatorDelaySeconds *int  `json:"spectatorDelaySeconds,omitempty"`
+	GhostChat             *bool `json:"ghostChat,omitempty"`
 }
 
 // ServerMessage is any message sent from server to client.
@@ -15,5 +38,21 @@ type ServerMessage struct {
 	Message  string      `json:"message,omitempty"`
 	Code     string      `json:"code,omitempty"`
 	PlayerID string      `json:"playerId,omitempty"`
+	Token    string      `json:"token,omitempty"` // secret resume token; lobby_created/lobby_joined only
 	State    interface{} `json:"state,omitempty"`
+
+	// state and delta: the lobby version the message brings the client to.
+	// A delta applies only on top of BaseVersion; on any other version the
+	// client should send "sync" for a full state.
+	Version     uint64    `json:"version,omitempty"`
+	BaseVersion uint64    `json:"baseVersion,omitempty"`
+	Ops         []PatchOp `json:"ops,omitempty"`
+
+	Chat    *usecase.ChatMessage  `json:"chat,omitempty"`
+	History []usecase.ChatMessage `json:"history,omitempty"` // chat_history
+
+	// ack and error: the ClientMessage.RequestID being answered. Error
+	// messages also keep the raw error text in Message.
+	RequestID string        `json:"requestId,omitempty"`
+	Error     *ErrorPayload `json:"error,omitempty"`
 }
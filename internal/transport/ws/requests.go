@@ -0,0 +1,120 @@
+package ws
+
+import (
+	"context"
+	"sync"
+)
+
+// requestLogSize is how many request IDs are remembered per player.
+const requestLogSize = 128
+
+// requestResult is the outcome of a client action with a request ID.
+type requestResult struct {
+	done chan struct{}
+	err  error
+}
+
+// wait blocks until the action has been handled and returns its error.
+// It is safe on a nil result.
+func (r *requestResult) wait() error {
+	if r == nil {
+		return nil
+	}
+	<-r.done
+	return r.err
+}
+
+// requestLog remembers the outcome of a player's recent actions so a retried
+// request ID is answered again instead of applied twice. It lives on the
+// Server rather than the connection so it survives reconnects.
+type requestLog struct {
+	mu      sync.Mutex
+	results map[string]*requestResult
+	order   []string // oldest first
+}
+
+func newRequestLog() *requestLog {
+	return &requestLog{results: make(map[string]*requestResult)}
+}
+
+// begin returns the result for id and whether it was seen before. A new
+// result must be finished by the caller.
+func (l *requestLog) begin(id string) (*requestResult, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r, ok := l.results[id]; ok {
+		return r, true
+	}
+	r := &requestResult{done: make(chan struct{})}
+	l.results[id] = r
+	l.order = append(l.order, id)
+	if len(l.order) > requestLogSize {
+		delete(l.results, l.order[0])
+		l.order = l.order[1:]
+	}
+	return r, false
+}
+
+// finish records err as the outcome of id. Errors that may go away on their
+// own (rate limits, server faults) are forgotten so a retry runs again.
+func (l *requestLog) finish(id string, r *requestResult, err error) {
+	if r == nil {
+		return
+	}
+	r.err = err
+	close(r.done)
+	if err == nil {
+		return
+	}
+	switch errorPayload(err, "").Category {
+	case CategoryRateLimited, CategoryInternal:
+		l.mu.Lock()
+		if l.results[id] == r {
+			delete(l.results, id)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// beginRequest looks up requestID in the player's log. It returns a nil log
+// and result for messages without a request ID, which are never deduplicated.
+func (s *Server) beginRequest(cc *clientConn, requestID string) (*requestLog, *requestResult, bool) {
+	if requestID == "" {
+		return nil, nil, false
+	}
+	s.mu.Lock()
+	byPlayer := s.requests[cc.lobbyCode]
+	if byPlayer == nil {
+		byPlayer = make(map[string]*requestLog)
+		s.requests[cc.lobbyCode] = byPlayer
+	}
+	reqs := byPlayer[cc.playerID]
+	if reqs == nil {
+		reqs = newRequestLog()
+		byPlayer[cc.playerID] = reqs
+	}
+	s.mu.Unlock()
+	r, seen := reqs.begin(requestID)
+	return reqs, r, seen
+}
+
+// forgetRequests drops the request log of a player who left the lobby.
+func (s *Server) forgetRequests(lobbyCode, playerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.requests[lobbyCode], playerID)
+	if len(s.requests[lobbyCode]) == 0 {
+		delete(s.requests, lobbyCode)
+	}
+}
+
+// reply answers requestID with an ack or the error it failed with.
+func (cc *clientConn) reply(ctx context.Context, requestID string, err error) error {
+	if err != nil {
+		return cc.sendError(ctx, requestID, err)
+	}
+	if requestID == "" {
+		return nil
+	}
+	return cc.send(ctx, ServerMessage{Type: "ack", RequestID: requestID})
+}
@@ -0,0 +1,205 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+
+	"game-server/internal/domain"
+	"game-server/internal/repository/inmem"
+	"game-server/internal/usecase"
+)
+
+func TestRequestLogRemembersOutcomes(t *testing.T) {
+	l := newRequestLog()
+	r, seen := l.begin("a")
+	if seen {
+		t.Fatalf("new ID reported as seen")
+	}
+	l.finish("a", r, domain.ErrNotPlayersTurn)
+
+	again, seen := l.begin("a")
+	if !seen || !errors.Is(again.wait(), domain.ErrNotPlayersTurn) {
+		t.Fatalf("seen=%v err=%v", seen, again.wait())
+	}
+}
+
+func TestRequestLogForgetsRetryableErrors(t *testing.T) {
+	l := newRequestLog()
+	for _, err := range []error{usecase.ErrChatRateLimited, errors.New("boom")} {
+		id := err.Error()
+		r, _ := l.begin(id)
+		l.finish(id, r, err)
+		if _, seen := l.begin(id); seen {
+			t.Fatalf("%v was remembered", err)
+		}
+	}
+}
+
+func TestRequestLogEvictsOldest(t *testing.T) {
+	l := newRequestLog()
+	for i := 0; i <= requestLogSize; i++ {
+		id := fmt.Sprint(i)
+		r, _ := l.begin(id)
+		l.finish(id, r, nil)
+	}
+	if len(l.results) != requestLogSize || len(l.order) != requestLogSize {
+		t.Fatalf("results=%d order=%d", len(l.results), len(l.order))
+	}
+	if _, seen := l.begin("0"); seen {
+		t.Fatalf("oldest ID was not evicted")
+	}
+	if _, seen := l.begin(fmt.Sprint(requestLogSize)); !seen {
+		t.Fatalf("newest ID was evicted")
+	}
+}
+
+func TestRequestLogWaitsForInFlightRequest(t *testing.T) {
+	l := newRequestLog()
+	r, _ := l.begin("a")
+	done := make(chan error, 1)
+	go func() {
+		dup, _ := l.begin("a")
+		done <- dup.wait()
+	}()
+	select {
+	case <-done:
+		t.Fatalf("retry answered before the original finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+	l.finish("a", r, nil)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestForgetRequests(t *testing.T) {
+	s := NewServer(usecase.NewLobbyService(inmem.NewLobbyStore()))
+	a := &clientConn{lobbyCode: "L", playerID: "a"}
+	b := &clientConn{lobbyCode: "L", playerID: "b"}
+	for _, cc := range []*clientConn{a, b} {
+		reqs, r, _ := s.beginRequest(cc, "x")
+		reqs.finish("x", r, nil)
+	}
+	s.forgetRequests("L", "a")
+	if _, _, seen := s.beginRequest(a, "x"); seen {
+		t.Fatalf("a's requests survived")
+	}
+	if _, _, seen := s.beginRequest(b, "x"); !seen {
+		t.Fatalf("b's requests were dropped")
+	}
+	s.forgetRequests("L", "a")
+	s.forgetRequests("L", "b")
+	if len(s.requests) != 0 {
+		t.Fatalf("requests=%v", s.requests)
+	}
+}
+
+// readReply reads until the ack or error for requestID arrives.
+func readReply(t *testing.T, c *websocket.Conn, requestID string) ServerMessage {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for {
+		var msg ServerMessage
+		if err := wsjson.Read(ctx, c, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.RequestID == requestID && (msg.Type == "ack" || msg.Type == "error") {
+			return msg
+		}
+	}
+}
+
+func TestRetriedPlayIsAppliedOnce(t *testing.T) {
+	service := usecase.NewLobbyService(inmem.NewLobbyStore())
+	ts := httptest.NewServer(NewServer(service).Handler())
+	defer ts.Close()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	created, _ := service.CreateLobby("A")
+	tokens := map[string]string{created.PlayerID: created.ResumeToken}
+	for _, name := range []string{"B", "C"} {
+		joined, err := service.JoinLobby(created.LobbyCode, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens[joined.PlayerID] = joined.ResumeToken
+	}
+	if err := service.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := service.ViewForPlayer(created.LobbyCode, created.PlayerID)
+	current := before.CurrentTurnPlayerID
+	play := ClientMessage{Type: "play_card", RequestID: "p1"}
+	mine, _ := service.ViewForPlayer(created.LobbyCode, current)
+	if mode, _ := domain.CardTarget(mine.You.Hand[0].Type); mode == domain.TargetOther {
+		for id := range tokens {
+			if id != current {
+				play.TargetID = id
+				break
+			}
+		}
+	}
+
+	ctx := context.Background()
+	connect := func() *websocket.Conn {
+		c, _, err := websocket.Dial(ctx, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := wsjson.Write(ctx, c, ClientMessage{Type: "resume", Code: created.LobbyCode, Token: tokens[current]}); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	drawCount := func() int {
+		v, _ := service.ViewForPlayer(created.LobbyCode, current)
+		return v.DrawCount
+	}
+
+	c := connect()
+	for i := 0; i < 2; i++ {
+		if err := wsjson.Write(ctx, c, play); err != nil {
+			t.Fatal(err)
+		}
+		if msg := readReply(t, c, "p1"); msg.Type != "ack" {
+			t.Fatalf("attempt %d: %+v", i, msg.Error)
+		}
+	}
+	if got := drawCount(); got != before.DrawCount-1 {
+		t.Fatalf("draw pile %d -> %d after a retried play", before.DrawCount, got)
+	}
+
+	// The log outlives the connection.
+	c.Close(websocket.StatusNormalClosure, "")
+	c = connect()
+	defer c.Close(websocket.StatusNormalClosure, "")
+	if err := wsjson.Write(ctx, c, play); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readReply(t, c, "p1"); msg.Type != "ack" {
+		t.Fatalf("after reconnect: %+v", msg.Error)
+	}
+	if got := drawCount(); got != before.DrawCount-1 {
+		t.Fatalf("play applied again after reconnect: draw pile %d", got)
+	}
+
+	// A new ID is a new action; out of turn now, and the error is replayed too.
+	play.RequestID = "p2"
+	for i := 0; i < 2; i++ {
+		if err := wsjson.Write(ctx, c, play); err != nil {
+			t.Fatal(err)
+		}
+		if msg := readReply(t, c, "p2"); msg.Type != "error" || msg.Error.Code != "not_players_turn" {
+			t.Fatalf("attempt %d: %+v", i, msg)
+		}
+	}
+}
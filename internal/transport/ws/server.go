@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"game-server/internal/bot"
 	"game-server/internal/usecase"
 
 	"nhooyr.io/websocket"
@@ -18,13 +19,15 @@ const (
 	writeTimeout = 10 * time.Second
 )
 
-var errInvalidHandshake = errors.New("first message must be create_lobby or join_lobby")
+var errInvalidHandshake = errors.New("first message must be create_lobby, join_lobby, resume or spectate")
 
 type Server struct {
 	service *usecase.LobbyService
 
-	mu      sync.RWMutex
-	clients map[string]map[string]*clientConn // lobbyCode -> playerID -> conn
+	mu         sync.RWMutex
+	clients    map[string]map[string]*clientConn // lobbyCode -> playerID -> conn
+	spectators map[string]map[*clientConn]struct{}
+	requests   map[string]map[string]*requestLog // lobbyCode -> playerID -> recent request IDs
 }
 
 type clientConn struct {
@@ -32,14 +35,51 @@ type clientConn struct {
 	mu sync.Mutex // serialize writes
 
 	lobbyCode string
-	playerID  string
+	playerID  string // empty for spectators
+	lang      string // language of error hints, from the handshake
+
+	// Players only: the last state sent, to compute deltas against.
+	viewMu  sync.Mutex
+	view    interface{} // decoded JSON; nil until the first snapshot
+	version uint64
+
+	// Spectators only: delayed outgoing state.
+	spectator bool
+	outbox    chan delayedMessage
+	closed    chan struct{}
 }
 
 func NewServer(service *usecase.LobbyService) *Server {
-	return &Server{
-		service: service,
-		clients: make(map[string]map[string]*clientConn),
+	s := &Server{
+		service:    service,
+		clients:    make(map[string]map[string]*clientConn),
+		spectators: make(map[string]map[*clientConn]struct{}),
+		requests:   make(map[string]map[string]*requestLog),
+	}
+	service.AddObserver(s)
+	return s
+}
+
+// LobbyChanged implements usecase.LobbyObserver.
+func (s *Server) LobbyChanged(code string) {
+	_ = s.broadcastLobbyState(context.Background(), code)
+}
+
+// LobbyClosed implements usecase.LobbyObserver by dropping the lobby's connections.
+func (s *Server) LobbyClosed(code string) {
+	s.mu.Lock()
+	conns := s.clients[code]
+	delete(s.clients, code)
+	delete(s.requests, code)
+	s.mu.Unlock()
+	for _, cc := range conns {
+		cc := cc
+		go func() {
+			_ = cc.send(context.Background(), ServerMessage{Type: "lobby_closed", Code: code})
+			_ = cc.ws.Close(websocket.StatusNormalClosure, "lobby closed")
+		}()
 	}
+	s.closeSpectators(code)
 }
 
 func (s *Server) Handler() http.Handler {
@@ -55,12 +95,19 @@ func (s *Server) Handler() http.Handler {
 		cc := &clientConn{ws: c}
 		ctx := r.Context()
 
-		if err := s.handshake(ctx, cc); err != nil {
-			_ = cc.send(ctx, ServerMessage{Type: "error", Message: err.Error()})
+		if requestID, err := s.handshake(ctx, cc); err != nil {
+			_ = cc.sendError(ctx, requestID, err)
+			return
+		}
+		if cc.spectator {
+			s.serveSpectator(ctx, cc)
 			return
 		}
 		defer s.unregister(cc)
 
+		if history, err := s.service.ChatHistory(cc.lobbyCode, cc.playerID); err == nil && len(history) > 0 {
+			_ = cc.send(ctx, ServerMessage{Type: "chat_history", History: history})
+		}
 		_ = s.broadcastLobbyState(ctx, cc.lobbyCode)
 
 		for {
@@ -72,89 +119,233 @@ func (s *Server) Handler() http.Handler {
 				return
 			}
 
+			// A retried request ID gets the original answer, so a resent
+			// play_card after a reconnect is not played twice.
+			reqs, res, retried := s.beginRequest(cc, msg.RequestID)
+			if retried {
+				_ = cc.reply(ctx, msg.RequestID, res.wait())
+				continue
+			}
+
+			broadcast, left := true, false
 			switch msg.Type {
 			case "start_game":
-				err = s.service.StartGame(cc.lobbyCode)
+				err = s.service.StartGame(cc.lobbyCode, cc.playerID)
+			case "update_settings":
+				err = s.updateSettings(cc, msg)
 			case "play_card":
-				// If targetId is set, treat as accusation, otherwise score.
-				if msg.TargetID != "" {
-					err = s.service.PlayAccusation(cc.lobbyCode, cc.playerID, msg.HandIndex, msg.TargetID)
-				} else {
-					err = s.service.PlayScore(cc.lobbyCode, cc.playerID, msg.HandIndex)
-				}
+				// The card in hand decides the effect; targetId is required
+				// or refused depending on the card type.
+				err = s.service.PlayCard(cc.lobbyCode, cc.playerID, msg.HandIndex, msg.TargetID)
+			case "discard_card":
+				err = s.service.DiscardCard(cc.lobbyCode, cc.playerID, msg.HandIndex)
+			case "pass":
+				err = s.service.PassTurn(cc.lobbyCode, cc.playerID)
 			case "call_over":
 				err = s.service.CallOver(cc.lobbyCode, cc.playerID)
+			case "call_meeting":
+				err = s.service.CallMeeting(cc.lobbyCode, cc.playerID)
+			case "vote":
+				// An empty targetId skips.
+				err = s.service.CastVote(cc.lobbyCode, cc.playerID, msg.TargetID)
+			case "chat":
+				var chat usecase.ChatMessage
+				var recipients []string
+				if chat, recipients, err = s.service.SendChat(cc.lobbyCode, cc.playerID, msg.Text); err == nil {
+					s.sendTo(ctx, cc.lobbyCode, recipients, ServerMessage{Type: "chat", Chat: &chat})
+					broadcast = false
+				}
+			case "team_chat":
+				var chat usecase.ChatMessage
+				var recipients []string
+				if chat, recipients, err = s.service.SendTeamChat(cc.lobbyCode, cc.playerID, msg.Text); err == nil {
+					s.sendTo(ctx, cc.lobbyCode, recipients, ServerMessage{Type: "team_chat", Chat: &chat})
+					broadcast = false
+				}
+			case "rematch":
+				err = s.service.RequestRematch(cc.lobbyCode, cc.playerID, msg.Start)
+			case "ready":
+				err = s.service.ReadyRematch(cc.lobbyCode, cc.playerID)
+			case "leave_lobby":
+				err = s.service.LeaveLobby(cc.lobbyCode, cc.playerID)
+				left = err == nil
+			case "add_bot":
+				err = s.addBot(cc, msg)
+			case "sync":
+				// The client saw a version gap; resend everything.
+				err = s.pushState(ctx, cc, true)
+				broadcast = false
+			case "kick_player":
+				if err = s.service.KickPlayer(cc.lobbyCode, cc.playerID, msg.TargetID); err == nil {
+					s.disconnect(ctx, cc.lobbyCode, msg.TargetID, ServerMessage{Type: "kicked", Code: cc.lobbyCode})
+					s.forgetRequests(cc.lobbyCode, msg.TargetID)
+				}
 			default:
-				err = nil
-				_ = cc.send(ctx, ServerMessage{Type: "error", Message: "unknown message type"})
+				err = errUnknownMessageType
 			}
+			reqs.finish(msg.RequestID, res, err)
 
+			_ = cc.reply(ctx, msg.RequestID, err)
 			if err != nil {
-				_ = cc.send(ctx, ServerMessage{Type: "error", Message: err.Error()})
 				continue
 			}
-			_ = s.broadcastLobbyState(ctx, cc.lobbyCode)
+			if left {
+				_ = cc.send(ctx, ServerMessage{Type: "left", Code: cc.lobbyCode})
+				s.unregister(cc)
+				s.forgetRequests(cc.lobbyCode, cc.playerID)
+				_ = s.broadcastLobbyState(ctx, cc.lobbyCode)
+				return
+			}
+			if broadcast {
+				_ = s.broadcastLobbyState(ctx, cc.lobbyCode)
+			}
 		}
 	})
 }
 
-func (s *Server) handshake(ctx context.Context, cc *clientConn) error {
+// addBot seats a server-side bot; name and difficulty are optional.
+func (s *Server) addBot(cc *clientConn, msg ClientMessage) error {
+	brain, err := bot.New(bot.Difficulty(msg.Difficulty))
+	if err != nil {
+		return err
+	}
+	_, err = s.service.AddBot(cc.lobbyCode, cc.playerID, msg.Name, brain)
+	return err
+}
+
+// updateSettings applies every setting in the message, or none if one is invalid.
+func (s *Server) updateSettings(cc *clientConn, msg ClientMessage) error {
+	settings := usecase.LobbySettings{
+		Rules:     msg.Rules,
+		Match:     msg.Match,
+		Deck:      msg.Deck,
+		DeckName:  msg.DeckName,
+		GhostChat: msg.GhostChat,
+	}
+	if msg.SpectatorDelaySeconds != nil {
+		delay := time.Duration(*msg.SpectatorDelaySeconds) * time.Second
+		settings.SpectatorDelay = &delay
+	}
+	return s.service.UpdateSettings(cc.lobbyCode, cc.playerID, settings)
+}
+
+// handshake reads the first message and binds cc to a lobby. It returns the
+// message's request ID so a failure can be reported against it.
+func (s *Server) handshake(ctx context.Context, cc *clientConn) (string, error) {
 	var msg ClientMessage
 	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
 	err := wsjson.Read(readCtx, cc.ws, &msg)
 	cancel()
 	if err != nil {
-		return err
+		return "", err
 	}
+	cc.lang = normalizeLang(msg.Lang)
 
 	switch msg.Type {
 	case "create_lobby":
 		res, err := s.service.CreateLobby(msg.Name)
 		if err != nil {
-			return err
+			return msg.RequestID, err
 		}
 		cc.lobbyCode = res.LobbyCode
 		cc.playerID = res.PlayerID
 		s.register(cc)
-		_ = cc.send(ctx, ServerMessage{Type: "lobby_created", Code: res.LobbyCode, PlayerID: res.PlayerID})
-		return nil
+		_ = cc.send(ctx, ServerMessage{Type: "lobby_created", Code: res.LobbyCode, PlayerID: res.PlayerID, Token: res.ResumeToken})
+		return msg.RequestID, nil
 	case "join_lobby":
 		res, err := s.service.JoinLobby(msg.Code, msg.Name)
 		if err != nil {
-			return err
+			return msg.RequestID, err
+		}
+		cc.lobbyCode = res.LobbyCode
+		cc.playerID = res.PlayerID
+		s.register(cc)
+		_ = cc.send(ctx, ServerMessage{Type: "lobby_joined", Code: res.LobbyCode, PlayerID: res.PlayerID, Token: res.ResumeToken})
+		return msg.RequestID, nil
+	case "resume":
+		// The current state is sent right after the handshake by Handler.
+		res, err := s.service.Resume(msg.Code, msg.Token)
+		if err != nil {
+			return msg.RequestID, err
 		}
 		cc.lobbyCode = res.LobbyCode
 		cc.playerID = res.PlayerID
 		s.register(cc)
-		_ = cc.send(ctx, ServerMessage{Type: "lobby_joined", Code: res.LobbyCode, PlayerID: res.PlayerID})
-		return nil
+		_ = cc.send(ctx, ServerMessage{Type: "resumed", Code: res.LobbyCode, PlayerID: res.PlayerID})
+		return msg.RequestID, nil
+	case "spectate":
+		if err := s.service.Spectate(msg.Code); err != nil {
+			return msg.RequestID, err
+		}
+		cc.lobbyCode = msg.Code
+		cc.spectator = true
+		_ = cc.send(ctx, ServerMessage{Type: "spectating", Code: msg.Code})
+		return msg.RequestID, nil
 	default:
-		return errInvalidHandshake
+		return msg.RequestID, errInvalidHandshake
 	}
 }
 
+// register binds cc to its seat, closing any previous connection for the same player.
 func (s *Server) register(cc *clientConn) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	defer func() {
+		s.mu.Unlock()
+		s.service.SetConnected(cc.lobbyCode, cc.playerID, true)
+	}()
 	m, ok := s.clients[cc.lobbyCode]
 	if !ok {
 		m = make(map[string]*clientConn)
 		s.clients[cc.lobbyCode] = m
 	}
+	if old := m[cc.playerID]; old != nil && old != cc {
+		go old.ws.Close(websocket.StatusPolicyViolation, "session resumed elsewhere")
+	}
 	m[cc.playerID] = cc
 }
 
 func (s *Server) unregister(cc *clientConn) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	m, ok := s.clients[cc.lobbyCode]
-	if !ok {
+	if !ok || m[cc.playerID] != cc {
+		// Already replaced by a resumed connection.
+		s.mu.Unlock()
 		return
 	}
 	delete(m, cc.playerID)
 	if len(m) == 0 {
 		delete(s.clients, cc.lobbyCode)
 	}
+	s.mu.Unlock()
+	s.service.SetConnected(cc.lobbyCode, cc.playerID, false)
+}
+
+// sendTo delivers msg to the given players' connections in the lobby.
+func (s *Server) sendTo(ctx context.Context, lobbyCode string, playerIDs []string, msg ServerMessage) {
+	s.mu.RLock()
+	conns := make([]*clientConn, 0, len(playerIDs))
+	for _, id := range playerIDs {
+		if cc := s.clients[lobbyCode][id]; cc != nil {
+			conns = append(conns, cc)
+		}
+	}
+	s.mu.RUnlock()
+	for _, cc := range conns {
+		_ = cc.send(ctx, msg)
+	}
+}
+
+// disconnect sends a final message to the player's connection, if any, and closes it.
+func (s *Server) disconnect(ctx context.Context, lobbyCode, playerID string, msg ServerMessage) {
+	s.mu.Lock()
+	cc := s.clients[lobbyCode][playerID]
+	s.mu.Unlock()
+	if cc == nil {
+		return
+	}
+	s.unregister(cc)
+	_ = cc.send(ctx, msg)
+	go cc.ws.Close(websocket.StatusNormalClosure, msg.Type)
 }
 
 func (cc *clientConn) send(ctx context.Context, msg ServerMessage) error {
@@ -165,6 +356,11 @@ func (cc *clientConn) send(ctx context.Context, msg ServerMessage) error {
 	return wsjson.Write(writeCtx, cc.ws, msg)
 }
 
+// sendError reports err to the client as a typed error in reply to requestID.
+func (cc *clientConn) sendError(ctx context.Context, requestID string, err error) error {
+	return cc.send(ctx, errorMessage(err, requestID, cc.lang))
+}
+
 func (s *Server) broadcastLobbyState(ctx context.Context, lobbyCode string) error {
 	playerIDs, err := s.service.LobbyPlayerIDs(lobbyCode)
 	if err != nil {
@@ -184,12 +380,10 @@ func (s *Server) broadcastLobbyState(ctx context.Context, lobbyCode string) erro
 		if cc == nil {
 			continue
 		}
-		view, err := s.service.ViewForPlayer(lobbyCode, playerID)
-		if err != nil {
-			_ = cc.send(ctx, ServerMessage{Type: "error", Message: err.Error()})
-			continue
+		if err := s.pushState(ctx, cc, false); err != nil {
+			_ = cc.sendError(ctx, "", err)
 		}
-		_ = cc.send(ctx, ServerMessage{Type: "state", State: view})
 	}
+	s.broadcastSpectators(lobbyCode)
 	return nil
 }
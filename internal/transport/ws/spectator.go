@@ -0,0 +1,140 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+const spectatorQueueSize = 64
+
+var errReadOnly = errors.New("spectators cannot send game actions")
+
+// delayedMessage is a spectator update held back until due.
+type delayedMessage struct {
+	due time.Time
+	msg ServerMessage
+}
+
+// serveSpectator runs a read-only connection until the client goes away.
+func (s *Server) serveSpectator(ctx context.Context, cc *clientConn) {
+	cc.outbox = make(chan delayedMessage, spectatorQueueSize)
+	cc.closed = make(chan struct{})
+	go cc.drainOutbox()
+
+	s.addSpectator(cc)
+	defer func() {
+		s.removeSpectator(cc)
+		close(cc.closed)
+		s.service.StopSpectating(cc.lobbyCode)
+		_ = s.broadcastLobbyState(context.Background(), cc.lobbyCode)
+	}()
+
+	_ = s.broadcastLobbyState(ctx, cc.lobbyCode)
+
+	for {
+		var msg ClientMessage
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		err := wsjson.Read(readCtx, cc.ws, &msg)
+		cancel()
+		if err != nil {
+			return
+		}
+		_ = cc.sendError(ctx, msg.RequestID, errReadOnly)
+	}
+}
+
+// enqueue schedules msg for delivery after delay. When the spectator is too
+// far behind the oldest pending update is dropped: every update is a full
+// snapshot, so the newest one must always get through.
+func (cc *clientConn) enqueue(msg ServerMessage, delay time.Duration) {
+	m := delayedMessage{due: time.Now().Add(delay), msg: msg}
+	for {
+		select {
+		case <-cc.closed:
+			return
+		case cc.outbox <- m:
+			return
+		default:
+		}
+		select {
+		case <-cc.outbox:
+		default:
+		}
+	}
+}
+
+// drainOutbox delivers delayed messages in order until the connection closes.
+func (cc *clientConn) drainOutbox() {
+	for {
+		select {
+		case <-cc.closed:
+			return
+		case m := <-cc.outbox:
+			if wait := time.Until(m.due); wait > 0 {
+				t := time.NewTimer(wait)
+				select {
+				case <-cc.closed:
+					t.Stop()
+					return
+				case <-t.C:
+				}
+			}
+			_ = cc.send(context.Background(), m.msg)
+		}
+	}
+}
+
+func (s *Server) addSpectator(cc *clientConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.spectators[cc.lobbyCode]
+	if !ok {
+		m = make(map[*clientConn]struct{})
+		s.spectators[cc.lobbyCode] = m
+	}
+	m[cc] = struct{}{}
+}
+
+func (s *Server) removeSpectator(cc *clientConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.spectators[cc.lobbyCode]
+	delete(m, cc)
+	if len(m) == 0 {
+		delete(s.spectators, cc.lobbyCode)
+	}
+}
+
+func (s *Server) broadcastSpectators(lobbyCode string) {
+	s.mu.RLock()
+	conns := make([]*clientConn, 0, len(s.spectators[lobbyCode]))
+	for cc := range s.spectators[lobbyCode] {
+		conns = append(conns, cc)
+	}
+	s.mu.RUnlock()
+	if len(conns) == 0 {
+		return
+	}
+
+	view, version, delay, err := s.service.SpectatorView(lobbyCode)
+	if err != nil {
+		return
+	}
+	for _, cc := range conns {
+		cc.enqueue(ServerMessage{Type: "state", Version: version, State: view}, delay)
+	}
+}
+
+func (s *Server) closeSpectators(lobbyCode string) {
+	s.mu.Lock()
+	conns := s.spectators[lobbyCode]
+	delete(s.spectators, lobbyCode)
+	s.mu.Unlock()
+	for cc := range conns {
+		go cc.ws.Close(websocket.StatusNormalClosure, "lobby closed")
+	}
+}
@@ -0,0 +1,67 @@
+package ws
+
+import (
+	"strconv"
+	"testing"
+
+	"game-server/internal/repository/inmem"
+	"game-server/internal/usecase"
+)
+
+func TestEnqueueDropsOldestWhenFull(t *testing.T) {
+	cc := &clientConn{
+		outbox: make(chan delayedMessage, spectatorQueueSize),
+		closed: make(chan struct{}),
+	}
+	total := spectatorQueueSize + 10
+	for i := 0; i < total; i++ {
+		cc.enqueue(ServerMessage{Type: "state", Code: strconv.Itoa(i)}, 0)
+	}
+	if len(cc.outbox) != spectatorQueueSize {
+		t.Fatalf("queued=%d", len(cc.outbox))
+	}
+	first := <-cc.outbox
+	if first.msg.Code != strconv.Itoa(total-spectatorQueueSize) {
+		t.Fatalf("oldest kept=%s", first.msg.Code)
+	}
+	var last delayedMessage
+	for len(cc.outbox) > 0 {
+		last = <-cc.outbox
+	}
+	if last.msg.Code != strconv.Itoa(total-1) {
+		t.Fatalf("newest=%s", last.msg.Code)
+	}
+}
+
+func TestSpectatorStateCarriesVersion(t *testing.T) {
+	service := usecase.NewLobbyService(inmem.NewLobbyStore())
+	s := NewServer(service)
+	created, _ := service.CreateLobby("A")
+	if err := service.Spectate(created.LobbyCode); err != nil {
+		t.Fatal(err)
+	}
+	cc := &clientConn{
+		lobbyCode: created.LobbyCode,
+		outbox:    make(chan delayedMessage, spectatorQueueSize),
+		closed:    make(chan struct{}),
+	}
+	s.addSpectator(cc)
+
+	var versions []uint64
+	for _, name := range []string{"B", "C"} {
+		// Joining notifies no observer, so broadcast as the read loop would.
+		if _, err := service.JoinLobby(created.LobbyCode, name); err != nil {
+			t.Fatal(err)
+		}
+		s.broadcastSpectators(created.LobbyCode)
+		m := <-cc.outbox
+		_, want, _ := service.VersionedView(created.LobbyCode, created.PlayerID)
+		if m.msg.Type != "state" || m.msg.Version != want {
+			t.Fatalf("type=%s version=%d want %d", m.msg.Type, m.msg.Version, want)
+		}
+		versions = append(versions, m.msg.Version)
+	}
+	if versions[1] <= versions[0] {
+		t.Fatalf("versions=%v", versions)
+	}
+}
@@ -3,9 +3,9 @@ package usecase
 // Assumptions
 //
 // The game design provided does not specify deck size/composition.
-// The server therefore defines a scalable deck in domain.buildDeck:
+// The server therefore defaults to domain.ClassicDeck, which scales with players:
 // per player: 6x(+1), 4x(0), 3x(-2), 3x(accusation).
 // This keeps score/accusation reasonably balanced and long enough to play.
 //
-// If you want an exact deck design later (e.g. fixed total card counts),
-// replace domain.buildDeck and keep the rest unchanged.
+// Other compositions are data: a domain.DeckSpec (JSON or YAML) can be registered in the
+// DeckCatalog at startup (DECKS_DIR) or uploaded by a lobby before the game starts.
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"game-server/internal/domain"
+)
+
+// DefaultBotDelay is how long bots wait before acting, so humans can follow.
+const DefaultBotDelay = 800 * time.Millisecond
+
+// BotMove makes a bot's move on the game. It runs with the lobby lock held.
+type BotMove func(g *domain.Game) error
+
+// BotBrain decides the moves of a bot seat. The bot package provides
+// strategies for each difficulty.
+//
+// Both methods are called with the lobby lock held and must not call back
+// into the LobbyService.
+type BotBrain interface {
+	// Observe is called with the bot's view after every change to the game.
+	Observe(view domain.GameView)
+	// Act returns the bot's next move, or false when it has nothing to do.
+	Act(view domain.GameView) (BotMove, bool)
+}
+
+// SetBotDelay changes how long bots wait before acting.
+func (s *LobbyService) SetBotDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.botDelay = d
+}
+
+// AddBot seats a bot driven by brain in the lobby. Host only; an empty name
+// defaults to "Bot N".
+func (s *LobbyService) AddBot(code, hostID, name string, brain BotBrain) (string, error) {
+	playerID := NewPlayerID()
+	err := s.mutateAsHost(code, hostID, func(lobby *Lobby, g *domain.Game) error {
+		if g.Status != domain.GameStatusLobby {
+			return ErrLobbyAlreadyStarted
+		}
+		if name == "" {
+			name = fmt.Sprintf("Bot %d", len(lobby.bots)+1)
+		}
+		if err := g.AddPlayer(&domain.Player{ID: playerID, Name: name}); err != nil {
+			return err
+		}
+		lobby.playerOrder = append(lobby.playerOrder, playerID)
+		lobby.bots[playerID] = brain
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return playerID, nil
+}
+
+// scheduleBots shows every bot the new state and arms a timer for the first
+// bot that wants to move. It must be called with the lobby lock held.
+func (s *LobbyService) scheduleBots(lobby *Lobby, g *domain.Game) {
+	if len(lobby.bots) == 0 {
+		return
+	}
+	s.mu.RLock()
+	delay := s.botDelay
+	s.mu.RUnlock()
+
+	next := ""
+	for _, id := range lobby.playerOrder {
+		brain := lobby.bots[id]
+		if brain == nil {
+			continue
+		}
+		view, err := lobby.viewFor(g, id)
+		if err != nil {
+			continue
+		}
+		brain.Observe(view)
+		if _, ok := brain.Act(view); ok && next == "" {
+			next = id
+		}
+	}
+
+	key := ""
+	if next != "" {
+		key = fmt.Sprintf("%s:%d", next, len(g.Events))
+	}
+	if key == lobby.botKey {
+		return
+	}
+	if lobby.botTimer != nil {
+		lobby.botTimer.Stop()
+		lobby.botTimer = nil
+	}
+	lobby.botKey = key
+	if key != "" {
+		lobby.botTimer = time.AfterFunc(delay, func() { s.botAct(lobby.Code, next, key) })
+	}
+}
+
+func (s *LobbyService) botAct(code, botID, key string) {
+	err := s.mutate(code, func(lobby *Lobby, g *domain.Game) error {
+		brain := lobby.bots[botID]
+		if brain == nil || lobby.botKey != key {
+			return errStaleTimer
+		}
+		lobby.botTimer = nil
+		lobby.botKey = ""
+		view, err := lobby.viewFor(g, botID)
+		if err != nil {
+			return err
+		}
+		move, ok := brain.Act(view)
+		if !ok {
+			return errStaleTimer
+		}
+		if err := move(g); err != nil {
+			// Nothing reschedules a bot whose move failed, so time its turn
+			// out rather than leave the table waiting.
+			if g.Status != domain.GameStatusInGame || g.CurrentPlayerID() != botID {
+				return err
+			}
+			return g.HandleTurnTimeout(botID)
+		}
+		return nil
+	})
+	if err == nil {
+		s.notify(code)
+	}
+}
+
+// isBot must be called with the lock held.
+func (l *Lobby) isBot(playerID string) bool {
+	return l.bots[playerID] != nil
+}
+
+// hasHumans must be called with the lock held.
+func (l *Lobby) hasHumans() bool {
+	for _, id := range l.playerOrder {
+		if !l.isBot(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// viewFor must be called with the lock held.
+func (l *Lobby) viewFor(g *domain.Game, playerID string) (domain.GameView, error) {
+	v, err := g.ViewFor(playerID, l.Code)
+	if err != nil {
+		return domain.GameView{}, err
+	}
+	l.decorate(&v)
+	return v, nil
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"game-server/internal/domain"
+)
+
+const (
+	// MaxChatLength is the longest chat message accepted, in characters.
+	MaxChatLength = 280
+	// ChatHistorySize is how many lobby messages are replayed to late joiners.
+	ChatHistorySize = 50
+
+	// A player may send at most ChatRateLimit messages per ChatRateWindow.
+	ChatRateLimit  = 5
+	ChatRateWindow = 10 * time.Second
+)
+
+// ChatChannel scopes who can read a chat message.
+type ChatChannel string
+
+const (
+	ChatChannelLobby ChatChannel = "lobby"
+	ChatChannelGhost ChatChannel = "ghost" // eliminated players only, when enabled
+	ChatChannelTeam  ChatChannel = "team"  // impostors only
+)
+
+// ChatMessage is a chat line as delivered to clients.
+type ChatMessage struct {
+	ID       int         `json:"id"` // per-lobby sequence; zero for team messages
+	Channel  ChatChannel `json:"channel"`
+	PlayerID string      `json:"playerId"`
+	Name     string      `json:"name"`
+	Text     string      `json:"text"`
+	SentAt   time.Time   `json:"sentAt"`
+}
+
+// SendTeamChat posts to the impostors' private channel. Recipients are decided
+// from the roles in the game, never by the client.
+func (s *LobbyService) SendTeamChat(code, playerID, text string) (ChatMessage, []string, error) {
+	text, err := normalizeChatText(text)
+	if err != nil {
+		return ChatMessage{}, nil, err
+	}
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return ChatMessage{}, nil, ErrLobbyNotFound
+	}
+	var (
+		msg        ChatMessage
+		recipients []string
+	)
+	err = lobby.WithLock(func(g *domain.Game) error {
+		if !lobby.isMember(playerID) {
+			return ErrPlayerNotInLobby
+		}
+		if err := lobby.allowChat(playerID, time.Now()); err != nil {
+			return err
+		}
+		ids, err := g.TeamChat(playerID, text)
+		if err != nil {
+			return err
+		}
+		recipients = ids
+		msg = ChatMessage{Channel: ChatChannelTeam, PlayerID: playerID, Name: playerName(g, playerID), Text: text, SentAt: time.Now().UTC()}
+		lobby.remember(msg)
+		return nil
+	})
+	return msg, recipients, err
+}
+
+// SendChat posts to the lobby channel, or to the ghost channel when the sender
+// is eliminated and the lobby restricts ghosts. It returns the recipients.
+func (s *LobbyService) SendChat(code, playerID, text string) (ChatMessage, []string, error) {
+	text, err := normalizeChatText(text)
+	if err != nil {
+		return ChatMessage{}, nil, err
+	}
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return ChatMessage{}, nil, ErrLobbyNotFound
+	}
+	var (
+		msg        ChatMessage
+		recipients []string
+	)
+	err = lobby.WithLock(func(g *domain.Game) error {
+		if !lobby.isMember(playerID) {
+			return ErrPlayerNotInLobby
+		}
+		if err := lobby.allowChat(playerID, time.Now()); err != nil {
+			return err
+		}
+		channel := ChatChannelLobby
+		if lobby.ghostChat && g.Status.Playing() && isEliminated(g, playerID) {
+			channel = ChatChannelGhost
+		}
+		lobby.chatSeq++
+		msg = ChatMessage{
+			ID:       lobby.chatSeq,
+			Channel:  channel,
+			PlayerID: playerID,
+			Name:     playerName(g, playerID),
+			Text:     text,
+			SentAt:   time.Now().UTC(),
+		}
+		lobby.remember(msg)
+		for _, id := range lobby.playerOrder {
+			if canReadChat(g, msg, id) {
+				recipients = append(recipients, id)
+			}
+		}
+		return nil
+	})
+	return msg, recipients, err
+}
+
+// ChatHistory returns the recent messages playerID is allowed to read,
+// including the team channel for the current game's impostors.
+func (s *LobbyService) ChatHistory(code, playerID string) ([]ChatMessage, error) {
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return nil, ErrLobbyNotFound
+	}
+	history := []ChatMessage{}
+	err := lobby.WithLock(func(g *domain.Game) error {
+		for _, msg := range lobby.chatHistory {
+			if canReadChat(g, msg, playerID) {
+				history = append(history, msg)
+			}
+		}
+		return nil
+	})
+	return history, err
+}
+
+// canReadChat reports whether playerID may read msg. Ghost messages become
+// public once the game is over; team messages stay with the impostors.
+func canReadChat(g *domain.Game, msg ChatMessage, playerID string) bool {
+	switch msg.Channel {
+	case ChatChannelGhost:
+		return !g.Status.Playing() || isEliminated(g, playerID)
+	case ChatChannelTeam:
+		return isImpostor(g, playerID)
+	}
+	return true
+}
+
+// remember adds msg to the chat history. It must be called with the lock held.
+func (l *Lobby) remember(msg ChatMessage) {
+	l.chatHistory = append(l.chatHistory, msg)
+	if len(l.chatHistory) > ChatHistorySize {
+		l.chatHistory = l.chatHistory[len(l.chatHistory)-ChatHistorySize:]
+	}
+}
+
+// forgetTeamChat drops the previous game's team messages before new roles are
+// dealt. It must be called with the lock held.
+func (l *Lobby) forgetTeamChat() {
+	kept := l.chatHistory[:0]
+	for _, msg := range l.chatHistory {
+		if msg.Channel != ChatChannelTeam {
+			kept = append(kept, msg)
+		}
+	}
+	l.chatHistory = kept
+}
+
+// allowChat applies the per-player rate limit. It must be called with the lock held.
+func (l *Lobby) allowChat(playerID string, now time.Time) error {
+	sent := l.chatSent[playerID]
+	for len(sent) > 0 && now.Sub(sent[0]) >= ChatRateWindow {
+		sent = sent[1:]
+	}
+	if len(sent) >= ChatRateLimit {
+		l.chatSent[playerID] = sent
+		return ErrChatRateLimited
+	}
+	l.chatSent[playerID] = append(sent, now)
+	return nil
+}
+
+// isImpostor mirrors the recipients of domain.Game.TeamChat.
+func isImpostor(g *domain.Game, playerID string) bool {
+	for _, p := range g.Players {
+		if p != nil && p.ID == playerID {
+			return p.Role == domain.RoleImpostor && !p.Left
+		}
+	}
+	return false
+}
+
+func isEliminated(g *domain.Game, playerID string) bool {
+	for _, p := range g.Players {
+		if p != nil && p.ID == playerID {
+			return p.Eliminated
+		}
+	}
+	return false
+}
+
+func normalizeChatText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrChatEmpty
+	}
+	if utf8.RuneCountInString(text) > MaxChatLength {
+		return "", ErrChatTooLong
+	}
+	return text, nil
+}
+
+func playerName(g *domain.Game, playerID string) string {
+	for _, p := range g.Players {
+		if p != nil && p.ID == playerID {
+			return p.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	"game-server/internal/domain"
+	"game-server/internal/usecase"
+)
+
+func TestChatHistoryAndLimits(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+
+	if _, _, err := s.SendChat(created.LobbyCode, created.PlayerID, "   "); err != usecase.ErrChatEmpty {
+		t.Fatalf("err=%v", err)
+	}
+	long := strings.Repeat("x", usecase.MaxChatLength+1)
+	if _, _, err := s.SendChat(created.LobbyCode, created.PlayerID, long); err != usecase.ErrChatTooLong {
+		t.Fatalf("err=%v", err)
+	}
+	for i := 0; i < usecase.ChatRateLimit; i++ {
+		if _, _, err := s.SendChat(created.LobbyCode, created.PlayerID, "hello"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := s.SendChat(created.LobbyCode, created.PlayerID, "hello"); err != usecase.ErrChatRateLimited {
+		t.Fatalf("err=%v", err)
+	}
+
+	late, _ := s.JoinLobby(created.LobbyCode, "B")
+	history, err := s.ChatHistory(created.LobbyCode, late.PlayerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != usecase.ChatRateLimit || history[0].SentAt.IsZero() || history[0].Name != "A" {
+		t.Fatalf("history=%+v", history)
+	}
+}
+
+func TestGhostChatReachesOnlyEliminatedPlayers(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	code, ids := created.LobbyCode, []string{created.PlayerID}
+	for _, name := range []string{"B", "C", "D", "E"} {
+		joined, _ := s.JoinLobby(code, name)
+		ids = append(ids, joined.PlayerID)
+	}
+	rules := domain.DefaultRules()
+	rules.MeetingsPerPlayer = 1
+	rules.MeetingOutcome = domain.MeetingOutcomeEject
+	rules.ImpostorBrackets = []domain.ImpostorBracket{{MinPlayers: 0, Impostors: 1}}
+	if err := s.UpdateRules(code, ids[0], rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(code, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	// Ghost chat is a lobby setting, so it can be switched on mid-game.
+	ghost := true
+	if err := s.UpdateSettings(code, ids[0], usecase.LobbySettings{GhostChat: &ghost}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Eject a good player so the game goes on without them.
+	var ejected string
+	for _, id := range ids[1:] {
+		if view, _ := s.ViewForPlayer(code, id); view.You.Role == domain.RoleGood {
+			ejected = id
+			break
+		}
+	}
+	if err := s.CallMeeting(code, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		target := ejected
+		if id == ejected {
+			target = ""
+		}
+		if err := s.CastVote(code, id, target); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msg, recipients, err := s.SendChat(code, ejected, "boo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Channel != usecase.ChatChannelGhost || len(recipients) != 1 || recipients[0] != ejected {
+		t.Fatalf("channel=%s recipients=%v", msg.Channel, recipients)
+	}
+	if history, _ := s.ChatHistory(code, ids[0]); len(history) != 0 {
+		t.Fatalf("living player sees %+v", history)
+	}
+}
+
+func TestTeamChatMembersAndHistory(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	code := created.LobbyCode
+	tokens := map[string]string{created.PlayerID: created.ResumeToken}
+	ids := []string{created.PlayerID}
+	for _, name := range []string{"B", "C", "D", "E"} {
+		joined, _ := s.JoinLobby(code, name)
+		ids = append(ids, joined.PlayerID)
+		tokens[joined.PlayerID] = joined.ResumeToken
+	}
+	rules := domain.DefaultRules()
+	// Two impostors, so one leaving does not end the game.
+	rules.ImpostorBrackets = []domain.ImpostorBracket{{MinPlayers: 0, Impostors: 2}}
+	if err := s.UpdateRules(code, ids[0], rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(code, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	var impostors []string
+	good := ""
+	for _, id := range ids {
+		if view, _ := s.ViewForPlayer(code, id); view.You.Role == domain.RoleImpostor {
+			impostors = append(impostors, id)
+		} else {
+			good = id
+		}
+	}
+
+	if _, _, err := s.SendTeamChat(code, impostors[0], "me first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.LeaveLobby(code, impostors[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.SendTeamChat(code, impostors[1], "still here?"); err != usecase.ErrPlayerNotInLobby {
+		t.Fatalf("left impostor: err=%v", err)
+	}
+	if _, _, err := s.SendTeamChat(code, "stranger", "hi"); err != usecase.ErrPlayerNotInLobby {
+		t.Fatalf("stranger: err=%v", err)
+	}
+
+	// The impostor reconnects and gets the team channel back.
+	resumed, err := s.Resume(code, tokens[impostors[0]])
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, _ := s.ChatHistory(code, resumed.PlayerID)
+	if len(history) != 1 || history[0].Channel != usecase.ChatChannelTeam || history[0].Text != "me first" {
+		t.Fatalf("impostor history=%+v", history)
+	}
+	if history, _ := s.ChatHistory(code, good); len(history) != 0 {
+		t.Fatalf("good player sees %+v", history)
+	}
+
+	// A rematch deals new roles, so the old team channel is dropped.
+	if err := s.CallOver(code, good); err != nil {
+		t.Fatal(err)
+	}
+	view, _ := s.ViewForPlayer(code, good)
+	if err := s.RequestRematch(code, view.HostID, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		if id != impostors[1] && id != view.HostID {
+			if err := s.ReadyRematch(code, id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for _, id := range ids {
+		if history, _ := s.ChatHistory(code, id); len(history) != 0 {
+			t.Fatalf("%s sees %+v after the rematch", id, history)
+		}
+	}
+}
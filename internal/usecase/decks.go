@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"game-server/internal/domain"
+)
+
+// DeckCatalog holds the named deck specs lobbies can pick from.
+// It is safe for concurrent use.
+type DeckCatalog struct {
+	mu    sync.RWMutex
+	decks map[string]domain.DeckSpec
+}
+
+func NewDeckCatalog() *DeckCatalog {
+	classic, tricks := domain.ClassicDeck(), domain.TricksDeck()
+	return &DeckCatalog{decks: map[string]domain.DeckSpec{classic.Name: classic, tricks.Name: tricks}}
+}
+
+// Register adds or replaces a deck spec by name.
+func (c *DeckCatalog) Register(d domain.DeckSpec) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.decks[d.Name] = d
+	return nil
+}
+
+func (c *DeckCatalog) Get(name string) (domain.DeckSpec, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	d, ok := c.decks[name]
+	return d, ok
+}
+
+// Names returns the registered deck names in sorted order.
+func (c *DeckCatalog) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, 0, len(c.decks))
+	for name := range c.decks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadDir registers every deck spec found in dir: *.json files, and *.yaml or
+// *.yml files for hand-written decks.
+func (c *DeckCatalog) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		var parse func([]byte) (domain.DeckSpec, error)
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".json":
+			parse = domain.ParseDeckSpec
+		case ".yaml", ".yml":
+			parse = domain.ParseDeckSpecYAML
+		}
+		if parse == nil || e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		d, err := parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		if err := c.Register(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
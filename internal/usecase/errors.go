@@ -3,8 +3,18 @@ package usecase
 import "errors"
 
 var (
-	ErrLobbyNotFound       = errors.New("lobby not found")
-	ErrLobbyCodeCollision  = errors.New("lobby code collision")
-	ErrPlayerNotInLobby    = errors.New("player not in lobby")
-	ErrLobbyAlreadyStarted = errors.New("lobby already started")
+	ErrLobbyNotFound         = errors.New("lobby not found")
+	ErrLobbyCodeCollision    = errors.New("lobby code collision")
+	ErrPlayerNotInLobby      = errors.New("player not in lobby")
+	ErrLobbyAlreadyStarted   = errors.New("lobby already started")
+	ErrDeckNotFound          = errors.New("deck not found")
+	ErrInvalidResumeToken    = errors.New("invalid resume token")
+	ErrNotHost               = errors.New("only the lobby host can do that")
+	ErrNoRematchVote         = errors.New("no rematch vote in progress")
+	ErrInvalidSpectatorDelay = errors.New("invalid spectator delay")
+	ErrChatEmpty             = errors.New("chat message is empty")
+	ErrChatTooLong           = errors.New("chat message is too long")
+	ErrChatRateLimited       = errors.New("sending chat messages too fast")
+
+	errStaleTimer = errors.New("timer no longer applies")
 )
@@ -24,3 +24,11 @@ func NewPlayerID() string {
 	id := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)
 	return strings.ToLower(id)
 }
+
+// NewResumeToken returns a secret that lets a player reclaim their seat.
+func NewResumeToken() string {
+	b := make([]byte, 20)
+	_, _ = rand.Read(b)
+	token := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)
+	return strings.ToLower(token)
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"game-server/internal/domain"
+)
+
+// JanitorConfig controls when abandoned lobbies are removed.
+type JanitorConfig struct {
+	Interval time.Duration
+
+	// IdleTTL removes lobbies that have had no connected player or spectator for this long.
+	IdleTTL time.Duration
+	// FinishedGrace removes finished games after this long.
+	FinishedGrace time.Duration
+	// LobbyMaxAge removes lobbies still waiting to start after this long.
+	LobbyMaxAge time.Duration
+}
+
+func DefaultJanitorConfig() JanitorConfig {
+	return JanitorConfig{
+		Interval:      time.Minute,
+		IdleTTL:       10 * time.Minute,
+		FinishedGrace: 30 * time.Minute,
+		LobbyMaxAge:   2 * time.Hour,
+	}
+}
+
+// Janitor periodically garbage-collects abandoned lobbies.
+type Janitor struct {
+	service *LobbyService
+	cfg     JanitorConfig
+}
+
+func NewJanitor(service *LobbyService, cfg JanitorConfig) *Janitor {
+	return &Janitor{service: service, cfg: cfg}
+}
+
+// Run sweeps every Interval until ctx is done.
+func (j *Janitor) Run(ctx context.Context) {
+	t := time.NewTicker(j.cfg.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			j.Sweep(now.UTC())
+		}
+	}
+}
+
+// Sweep removes every lobby that is expired at now and returns their codes.
+func (j *Janitor) Sweep(now time.Time) []string {
+	var removed []string
+	for _, lobby := range j.service.store.List() {
+		expired := false
+		_ = lobby.WithLock(func(g *domain.Game) error {
+			// Mark it closed under the same lock so nobody joins in between.
+			expired = !lobby.closed && j.expired(lobby, now)
+			lobby.closed = lobby.closed || expired
+			return nil
+		})
+		if expired {
+			j.service.closeLobby(lobby.Code)
+			removed = append(removed, lobby.Code)
+		}
+	}
+	return removed
+}
+
+// expired must be called with the lobby lock held.
+func (j *Janitor) expired(lobby *Lobby, now time.Time) bool {
+	switch {
+	case len(lobby.connected) == 0 && lobby.spectators == 0 && now.Sub(lobby.lastActivity) > j.cfg.IdleTTL:
+		return true
+	case lobby.status == domain.GameStatusFinished && now.Sub(lobby.statusSince) > j.cfg.FinishedGrace:
+		return true
+	case lobby.status == domain.GameStatusLobby && now.Sub(lobby.statusSince) > j.cfg.LobbyMaxAge:
+		return true
+	}
+	return false
+}
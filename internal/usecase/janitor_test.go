@@ -0,0 +1,33 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"game-server/internal/usecase"
+)
+
+func TestJanitorRemovesIdleLobbies(t *testing.T) {
+	s := newService()
+	idle, _ := s.CreateLobby("A")
+	active, _ := s.CreateLobby("B")
+	s.SetConnected(active.LobbyCode, active.PlayerID, true)
+
+	cfg := usecase.DefaultJanitorConfig()
+	j := usecase.NewJanitor(s, cfg)
+	if removed := j.Sweep(time.Now().UTC()); len(removed) != 0 {
+		t.Fatalf("removed fresh lobbies: %v", removed)
+	}
+	removed := j.Sweep(time.Now().UTC().Add(cfg.IdleTTL + time.Second))
+	if len(removed) != 1 || removed[0] != idle.LobbyCode {
+		t.Fatalf("removed=%v", removed)
+	}
+	if _, err := s.LobbyPlayerIDs(active.LobbyCode); err != nil {
+		t.Fatalf("connected lobby removed: %v", err)
+	}
+
+	removed = j.Sweep(time.Now().UTC().Add(cfg.LobbyMaxAge + time.Second))
+	if len(removed) != 1 || removed[0] != active.LobbyCode {
+		t.Fatalf("stale lobby not removed: %v", removed)
+	}
+}
@@ -14,14 +14,167 @@ type Lobby struct {
 	Code      string
 	CreatedAt time.Time
 
-	mu sync.Mutex
-	g  *domain.Game
+	mu     sync.Mutex
+	g      *domain.Game
+	closed bool // set under the lock before removal, so late joins and actions fail
 
-	playerOrder []string // stable join order for UI purposes
+	playerOrder []string          // stable join order for UI purposes
+	hostID      string            // the creator, then the longest-standing member
+	tokens      map[string]string // resume token -> playerID
+
+	turnTimer    *time.Timer // runs out the current turn or the open meeting
+	turnDeadline time.Time   // zero when no timer is running
+	timerKey     string      // what turnTimer was armed for
+
+	connected    map[string]bool // playerID -> has a live connection
+	lastActivity time.Time       // last connect/disconnect; idle lobbies are measured from here
+	status       domain.GameStatus
+	statusSince  time.Time
+
+	spectators     int
+	spectatorDelay time.Duration
+
+	chatSeq     int
+	chatHistory []ChatMessage
+	chatSent    map[string][]time.Time // playerID -> recent send times, for rate limiting
+	ghostChat   bool
+
+	bots     map[string]BotBrain // playerID -> brain, for seats played by the server
+	botTimer *time.Timer
+	botKey   string // which bot move botTimer is armed for
+
+	// version counts changes to what players see; transports use it to
+	// order updates and detect missed ones.
+	version uint64
+
+	rematch    *rematchVote
+	match      *domain.Match          // nil for single games
+	lastReplay *domain.ReplayDocument // previous game, kept across a rematch
 }
 
 func NewLobby(code string) *Lobby {
-	return &Lobby{Code: code, CreatedAt: time.Now().UTC(), g: domain.NewLobbyGame()}
+	now := time.Now().UTC()
+	return &Lobby{
+		Code:         code,
+		CreatedAt:    now,
+		g:            domain.NewLobbyGame(),
+		tokens:       make(map[string]string),
+		connected:    make(map[string]bool),
+		chatSent:     make(map[string][]time.Time),
+		bots:         make(map[string]BotBrain),
+		lastActivity: now,
+		status:       domain.GameStatusLobby,
+		statusSince:  now,
+	}
+}
+
+// trackStatus notes when the game changed status. It must be called with the lock held.
+func (l *Lobby) trackStatus(now time.Time) {
+	if l.g.Status != l.status {
+		l.status = l.g.Status
+		l.statusSince = now
+	}
+}
+
+// addMember records a newly seated player; the first one becomes host.
+// It must be called with the lock held.
+func (l *Lobby) addMember(playerID string) string {
+	l.playerOrder = append(l.playerOrder, playerID)
+	if l.hostID == "" {
+		l.hostID = playerID
+	}
+	return l.issueToken(playerID)
+}
+
+// removeMember forgets a departed player, handing the host role to the next
+// human member in join order. It must be called with the lock held.
+func (l *Lobby) removeMember(playerID string) {
+	for i, id := range l.playerOrder {
+		if id == playerID {
+			l.playerOrder = append(l.playerOrder[:i], l.playerOrder[i+1:]...)
+			break
+		}
+	}
+	for token, id := range l.tokens {
+		if id == playerID {
+			delete(l.tokens, token)
+		}
+	}
+	delete(l.chatSent, playerID)
+	delete(l.bots, playerID)
+	if l.hostID == playerID {
+		l.hostID = ""
+		for _, id := range l.playerOrder {
+			if !l.isBot(id) {
+				l.hostID = id
+				break
+			}
+		}
+	}
+}
+
+// isMember must be called with the lock held.
+func (l *Lobby) isMember(playerID string) bool {
+	for _, id := range l.playerOrder {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// decorate adds lobby-level state to a game view. It must be called with the lock held.
+func (l *Lobby) decorate(v *domain.GameView) {
+	v.HostID = l.hostID
+	v.Spectators = l.spectators
+	v.SpectatorDelaySeconds = int(l.spectatorDelay / time.Second)
+	v.GhostChat = l.ghostChat
+	v.Rematch = l.rematchView()
+	if l.match != nil {
+		v.Match = l.match.View()
+	}
+	for i := range v.Players {
+		v.Players[i].Bot = l.isBot(v.Players[i].ID)
+	}
+	if !l.turnDeadline.IsZero() {
+		deadline := l.turnDeadline
+		if v.Status == domain.GameStatusMeeting {
+			v.MeetingDeadline = &deadline
+		} else {
+			v.TurnDeadline = &deadline
+		}
+	}
+}
+
+// bump marks a visible change to the lobby. It must be called with the lock held.
+func (l *Lobby) bump() {
+	l.version++
+}
+
+// stopTimers must be called with the lock held.
+func (l *Lobby) stopTimers() {
+	if l.turnTimer != nil {
+		l.turnTimer.Stop()
+		l.turnTimer = nil
+	}
+	l.turnDeadline = time.Time{}
+	l.timerKey = ""
+}
+
+// stopBots must be called with the lock held.
+func (l *Lobby) stopBots() {
+	if l.botTimer != nil {
+		l.botTimer.Stop()
+		l.botTimer = nil
+	}
+	l.botKey = ""
+}
+
+// issueToken must be called with the lock held.
+func (l *Lobby) issueToken(playerID string) string {
+	token := NewResumeToken()
+	l.tokens[token] = playerID
+	return token
 }
 
 func (l *Lobby) WithLock(fn func(g *domain.Game) error) error {
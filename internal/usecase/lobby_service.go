@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"sync"
+	"time"
+
 	"game-server/internal/domain"
 )
 
@@ -10,21 +13,100 @@ type LobbyStore interface {
 	Create(code string, lobby *Lobby) error
 	Get(code string) (*Lobby, bool)
 	Delete(code string)
+	List() []*Lobby
 }
 
 // LobbyService orchestrates lobby creation/joining and game actions.
 // It is transport-agnostic.
 type LobbyService struct {
 	store LobbyStore
+	decks *DeckCatalog
+
+	mu        sync.RWMutex
+	observers []LobbyObserver
+	botDelay  time.Duration
+}
+
+// LobbyObserver is told about state changes the service makes on its own
+// (e.g. an expired turn timer), which no client request will broadcast.
+type LobbyObserver interface {
+	LobbyChanged(code string)
+	// LobbyClosed is called after the lobby was removed from the store.
+	LobbyClosed(code string)
+}
+
+// AddObserver registers o for lobby change notifications.
+func (s *LobbyService) AddObserver(o LobbyObserver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers = append(s.observers, o)
+}
+
+func (s *LobbyService) notifyClosed(code string) {
+	s.mu.RLock()
+	observers := append([]LobbyObserver(nil), s.observers...)
+	s.mu.RUnlock()
+	for _, o := range observers {
+		o.LobbyClosed(code)
+	}
+}
+
+func (s *LobbyService) notify(code string) {
+	s.mu.RLock()
+	observers := append([]LobbyObserver(nil), s.observers...)
+	s.mu.RUnlock()
+	for _, o := range observers {
+		o.LobbyChanged(code)
+	}
+}
+
+// mutateAsHost is mutate restricted to the lobby host.
+func (s *LobbyService) mutateAsHost(code, playerID string, fn func(lobby *Lobby, g *domain.Game) error) error {
+	return s.mutate(code, func(lobby *Lobby, g *domain.Game) error {
+		if lobby.hostID != playerID {
+			return ErrNotHost
+		}
+		return fn(lobby, g)
+	})
+}
+
+// mutate runs fn under the lobby lock and re-arms the turn timer and bots afterwards.
+func (s *LobbyService) mutate(code string, fn func(lobby *Lobby, g *domain.Game) error) error {
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.WithLock(func(g *domain.Game) error {
+		if lobby.closed {
+			return ErrLobbyNotFound
+		}
+		if err := fn(lobby, g); err != nil {
+			return err
+		}
+		if lobby.match != nil {
+			lobby.match.RecordRound()
+		}
+		lobby.trackStatus(time.Now().UTC())
+		s.armTurnTimer(lobby, g)
+		s.scheduleBots(lobby, g)
+		lobby.bump()
+		return nil
+	})
 }
 
 func NewLobbyService(store LobbyStore) *LobbyService {
-	return &LobbyService{store: store}
+	return &LobbyService{store: store, decks: NewDeckCatalog(), botDelay: DefaultBotDelay}
+}
+
+// Decks returns the catalog of named decks lobbies can select.
+func (s *LobbyService) Decks() *DeckCatalog {
+	return s.decks
 }
 
 type CreateLobbyResult struct {
-	LobbyCode string
-	PlayerID  string
+	LobbyCode   string
+	PlayerID    string
+	ResumeToken string
 }
 
 func (s *LobbyService) CreateLobby(playerName string) (CreateLobbyResult, error) {
@@ -32,8 +114,14 @@ func (s *LobbyService) CreateLobby(playerName string) (CreateLobbyResult, error)
 		code := NewLobbyCode()
 		lobby := NewLobby(code)
 		playerID := NewPlayerID()
+		var token string
 		err := lobby.WithLock(func(g *domain.Game) error {
-			return g.AddPlayer(&domain.Player{ID: playerID, Name: playerName})
+			if err := g.AddPlayer(&domain.Player{ID: playerID, Name: playerName}); err != nil {
+				return err
+			}
+			token = lobby.addMember(playerID)
+			lobby.bump()
+			return nil
 		})
 		if err != nil {
 			return CreateLobbyResult{}, err
@@ -44,14 +132,15 @@ func (s *LobbyService) CreateLobby(playerName string) (CreateLobbyResult, error)
 			}
 			return CreateLobbyResult{}, err
 		}
-		return CreateLobbyResult{LobbyCode: code, PlayerID: playerID}, nil
+		return CreateLobbyResult{LobbyCode: code, PlayerID: playerID, ResumeToken: token}, nil
 	}
 	return CreateLobbyResult{}, ErrLobbyCodeCollision
 }
 
 type JoinLobbyResult struct {
-	LobbyCode string
-	PlayerID  string
+	LobbyCode   string
+	PlayerID    string
+	ResumeToken string
 }
 
 func (s *LobbyService) JoinLobby(code string, playerName string) (JoinLobbyResult, error) {
@@ -60,69 +149,267 @@ func (s *LobbyService) JoinLobby(code string, playerName string) (JoinLobbyResul
 		return JoinLobbyResult{}, ErrLobbyNotFound
 	}
 	playerID := NewPlayerID()
+	var token string
 	if err := lobby.WithLock(func(g *domain.Game) error {
-		return g.AddPlayer(&domain.Player{ID: playerID, Name: playerName})
+		if lobby.closed {
+			return ErrLobbyNotFound
+		}
+		if err := g.AddPlayer(&domain.Player{ID: playerID, Name: playerName}); err != nil {
+			return err
+		}
+		token = lobby.addMember(playerID)
+		lobby.bump()
+		return nil
 	}); err != nil {
 		return JoinLobbyResult{}, err
 	}
-	return JoinLobbyResult{LobbyCode: code, PlayerID: playerID}, nil
+	return JoinLobbyResult{LobbyCode: code, PlayerID: playerID, ResumeToken: token}, nil
 }
 
-func (s *LobbyService) StartGame(code string) error {
+type ResumeResult struct {
+	LobbyCode string
+	PlayerID  string
+}
+
+// Resume maps a resume token back to the player's seat in the lobby.
+func (s *LobbyService) Resume(code, token string) (ResumeResult, error) {
 	lobby, ok := s.store.Get(code)
 	if !ok {
-		return ErrLobbyNotFound
+		return ResumeResult{}, ErrLobbyNotFound
 	}
-	return lobby.WithLock(func(g *domain.Game) error {
-		return g.Start()
+	var playerID string
+	err := lobby.WithLock(func(g *domain.Game) error {
+		if lobby.closed {
+			return ErrLobbyNotFound
+		}
+		id, ok := lobby.tokens[token]
+		if !ok || token == "" {
+			return ErrInvalidResumeToken
+		}
+		playerID = id
+		return nil
 	})
+	if err != nil {
+		return ResumeResult{}, err
+	}
+	return ResumeResult{LobbyCode: code, PlayerID: playerID}, nil
 }
 
-func (s *LobbyService) PlayScore(code, playerID string, handIndex int) error {
+// LeaveLobby removes the player from the lobby, deleting the lobby once only bots are left.
+func (s *LobbyService) LeaveLobby(code, playerID string) error {
+	return s.removePlayer(code, playerID, nil)
+}
+
+// KickPlayer removes targetID from the lobby on behalf of the host.
+func (s *LobbyService) KickPlayer(code, hostID, targetID string) error {
+	return s.removePlayer(code, targetID, func(lobby *Lobby) error {
+		if lobby.hostID != hostID {
+			return ErrNotHost
+		}
+		if targetID == hostID {
+			return domain.ErrTargetInvalid
+		}
+		return nil
+	})
+}
+
+func (s *LobbyService) removePlayer(code, playerID string, authorize func(lobby *Lobby) error) error {
+	empty := false
+	err := s.mutate(code, func(lobby *Lobby, g *domain.Game) error {
+		if authorize != nil {
+			if err := authorize(lobby); err != nil {
+				return err
+			}
+		}
+		if !lobby.isMember(playerID) {
+			return ErrPlayerNotInLobby
+		}
+		if err := g.RemovePlayer(playerID); err != nil {
+			return err
+		}
+		if g.MeetingComplete() {
+			// The departed player was the last ballot the meeting waited for.
+			if err := g.CloseMeeting(); err != nil {
+				return err
+			}
+		}
+		lobby.removeMember(playerID)
+		delete(lobby.connected, playerID)
+		empty = !lobby.hasHumans()
+		if empty {
+			// Refuse joins from now on; closeLobby runs after the lock is released.
+			lobby.closed = true
+			return nil
+		}
+		if lobby.rematch != nil {
+			// The departed player may have been the last one not ready.
+			return lobby.maybeRematch()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if empty {
+		s.closeLobby(code)
+	}
+	return nil
+}
+
+// closeLobby stops the lobby's timers and removes it from the store.
+func (s *LobbyService) closeLobby(code string) {
 	lobby, ok := s.store.Get(code)
 	if !ok {
-		return ErrLobbyNotFound
+		return
 	}
-	return lobby.WithLock(func(g *domain.Game) error {
-		return g.PlayScoreCard(playerID, handIndex)
+	_ = lobby.WithLock(func(*domain.Game) error {
+		lobby.closed = true
+		lobby.stopTimers()
+		lobby.stopBots()
+		return nil
 	})
+	s.store.Delete(code)
+	s.notifyClosed(code)
 }
 
-func (s *LobbyService) PlayAccusation(code, playerID string, handIndex int, targetID string) error {
+// SetConnected records whether the player currently has a live connection.
+func (s *LobbyService) SetConnected(code, playerID string, connected bool) {
 	lobby, ok := s.store.Get(code)
 	if !ok {
-		return ErrLobbyNotFound
+		return
 	}
-	return lobby.WithLock(func(g *domain.Game) error {
+	_ = lobby.WithLock(func(*domain.Game) error {
+		if connected {
+			lobby.connected[playerID] = true
+		} else {
+			delete(lobby.connected, playerID)
+		}
+		lobby.lastActivity = time.Now().UTC()
+		return nil
+	})
+}
+
+// StartGame starts the game on behalf of the host.
+func (s *LobbyService) StartGame(code, playerID string) error {
+	return s.mutateAsHost(code, playerID, func(_ *Lobby, g *domain.Game) error {
+		return g.Start()
+	})
+}
+
+func (s *LobbyService) PlayScore(code, playerID string, handIndex int) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		return g.PlayScoreCard(playerID, handIndex)
+	})
+}
+
+func (s *LobbyService) PlayAccusation(code, playerID string, handIndex int, targetID string) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
 		return g.PlayAccusationCard(playerID, handIndex, targetID)
 	})
 }
 
+// PlayCard plays any card; the card's effect decides whether targetID is needed.
+func (s *LobbyService) PlayCard(code, playerID string, handIndex int, targetID string) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		return g.PlayCard(playerID, handIndex, targetID)
+	})
+}
+
+// DiscardCard discards a card face-down and draws a replacement, if the rules allow it.
+func (s *LobbyService) DiscardCard(code, playerID string, handIndex int) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		return g.DiscardCard(playerID, handIndex)
+	})
+}
+
+// PassTurn ends the player's turn without playing, if the rules allow it.
+func (s *LobbyService) PassTurn(code, playerID string) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		return g.PassTurn(playerID)
+	})
+}
+
 func (s *LobbyService) CallOver(code, playerID string) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		return g.CallOver(playerID)
+	})
+}
+
+// CallMeeting pauses turns for a vote; the lobby closes it once every living
+// player has voted or Rules.MeetingVoteSeconds run out.
+func (s *LobbyService) CallMeeting(code, playerID string) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		return g.CallMeeting(playerID)
+	})
+}
+
+// CastVote records a meeting ballot; an empty targetID skips.
+func (s *LobbyService) CastVote(code, playerID, targetID string) error {
+	return s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		if err := g.CastVote(playerID, targetID); err != nil {
+			return err
+		}
+		if g.MeetingComplete() {
+			return g.CloseMeeting()
+		}
+		return nil
+	})
+}
+
+func (s *LobbyService) ViewForPlayer(code, playerID string) (domain.GameView, error) {
+	view, _, err := s.VersionedView(code, playerID)
+	return view, err
+}
+
+// VersionedView is ViewForPlayer plus the lobby version the view reflects.
+// Versions only grow; a view with a higher version is the more recent one.
+func (s *LobbyService) VersionedView(code, playerID string) (domain.GameView, uint64, error) {
 	lobby, ok := s.store.Get(code)
 	if !ok {
-		return ErrLobbyNotFound
+		return domain.GameView{}, 0, ErrLobbyNotFound
 	}
-	return lobby.WithLock(func(g *domain.Game) error {
-		return g.CallOver(playerID)
+	var (
+		view    domain.GameView
+		version uint64
+	)
+	err := lobby.WithLock(func(g *domain.Game) error {
+		v, err := lobby.viewFor(g, playerID)
+		view, version = v, lobby.version
+		return err
 	})
+	return view, version, err
 }
 
-func (s *LobbyService) ViewForPlayer(code, playerID string) (domain.GameView, error) {
+// Replay exports the lobby's finished game, or the previous one after a rematch.
+func (s *LobbyService) Replay(code string) (domain.ReplayDocument, error) {
 	lobby, ok := s.store.Get(code)
 	if !ok {
-		return domain.GameView{}, ErrLobbyNotFound
+		return domain.ReplayDocument{}, ErrLobbyNotFound
 	}
-	var view domain.GameView
+	var doc domain.ReplayDocument
 	err := lobby.WithLock(func(g *domain.Game) error {
-		v, err := g.ViewFor(playerID, code)
+		if g.Status != domain.GameStatusFinished && lobby.lastReplay != nil {
+			doc = *lobby.lastReplay
+			return nil
+		}
+		d, err := g.ReplayDocument(code)
 		if err != nil {
 			return err
 		}
-		view = v
+		doc = d
 		return nil
 	})
-	return view, err
+	return doc, err
+}
+
+// ReplayViewAt returns the finished game's state at the end of turn, as seen
+// by playerID, or omniscient when playerID is empty.
+func (s *LobbyService) ReplayViewAt(code string, turn int, playerID string) (domain.GameView, error) {
+	doc, err := s.Replay(code)
+	if err != nil {
+		return domain.GameView{}, err
+	}
+	return doc.ViewAt(turn, playerID)
 }
 
 func (s *LobbyService) LobbyPlayerIDs(code string) ([]string, error) {
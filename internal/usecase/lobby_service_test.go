@@ -0,0 +1,557 @@
+package usecase_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"game-server/internal/domain"
+	"game-server/internal/repository/inmem"
+	"game-server/internal/usecase"
+)
+
+func newService() *usecase.LobbyService {
+	return usecase.NewLobbyService(inmem.NewLobbyStore())
+}
+
+func TestResumeReturnsSameSeat(t *testing.T) {
+	s := newService()
+	created, err := s.CreateLobby("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined, err := s.JoinLobby(created.LobbyCode, "B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Resume(created.LobbyCode, joined.ResumeToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PlayerID != joined.PlayerID {
+		t.Fatalf("resumed as %s, want %s", res.PlayerID, joined.PlayerID)
+	}
+	if _, err := s.Resume(created.LobbyCode, "nope"); err != usecase.ErrInvalidResumeToken {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+type observerFunc func(code string)
+
+func (f observerFunc) LobbyChanged(code string) { f(code) }
+func (f observerFunc) LobbyClosed(string)       {}
+
+func TestTurnTimerExpires(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	for _, name := range []string{"B", "C"} {
+		if _, err := s.JoinLobby(created.LobbyCode, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules := domain.DefaultRules()
+	rules.TurnTimeoutSeconds = 1
+	rules.TimeoutAction = domain.TimeoutActionSkip
+	if err := s.UpdateRules(created.LobbyCode, created.PlayerID, rules); err != nil {
+		t.Fatal(err)
+	}
+
+	changed := make(chan string, 1)
+	s.AddObserver(observerFunc(func(code string) { changed <- code }))
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	view, err := s.ViewForPlayer(created.LobbyCode, created.PlayerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.TurnDeadline == nil {
+		t.Fatalf("no deadline in view")
+	}
+	first := view.CurrentTurnPlayerID
+
+	select {
+	case <-changed:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("timer did not fire")
+	}
+	view, _ = s.ViewForPlayer(created.LobbyCode, created.PlayerID)
+	if view.CurrentTurnPlayerID == first {
+		t.Fatalf("turn did not advance")
+	}
+}
+
+func TestLeaveLastPlayerDeletesLobby(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	if err := s.LeaveLobby(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.LobbyPlayerIDs(created.LobbyCode); err != usecase.ErrLobbyNotFound {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestKickRequiresHost(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	b, _ := s.JoinLobby(created.LobbyCode, "B")
+	c, _ := s.JoinLobby(created.LobbyCode, "C")
+	if err := s.KickPlayer(created.LobbyCode, b.PlayerID, c.PlayerID); err != usecase.ErrNotHost {
+		t.Fatalf("err=%v", err)
+	}
+	if err := s.KickPlayer(created.LobbyCode, created.PlayerID, c.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Resume(created.LobbyCode, c.ResumeToken); err != usecase.ErrInvalidResumeToken {
+		t.Fatalf("kicked player can resume: %v", err)
+	}
+}
+
+func TestHostOnlyActionsAndTransfer(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	b, _ := s.JoinLobby(created.LobbyCode, "B")
+	if _, err := s.JoinLobby(created.LobbyCode, "C"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(created.LobbyCode, b.PlayerID); err != usecase.ErrNotHost {
+		t.Fatalf("err=%v", err)
+	}
+	if err := s.LeaveLobby(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	view, err := s.ViewForPlayer(created.LobbyCode, b.PlayerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.HostID != b.PlayerID {
+		t.Fatalf("host=%s, want %s", view.HostID, b.PlayerID)
+	}
+}
+
+// finishGame starts a three-player game and has a good player call over.
+func finishGame(t *testing.T, s *usecase.LobbyService) (code string, ids []string) {
+	t.Helper()
+	created, _ := s.CreateLobby("A")
+	ids = []string{created.PlayerID}
+	for _, name := range []string{"B", "C"} {
+		res, err := s.JoinLobby(created.LobbyCode, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, res.PlayerID)
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		view, _ := s.ViewForPlayer(created.LobbyCode, id)
+		if view.You.Role == domain.RoleGood {
+			if err := s.CallOver(created.LobbyCode, id); err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	return created.LobbyCode, ids
+}
+
+func TestRematchAfterEveryoneReady(t *testing.T) {
+	s := newService()
+	code, ids := finishGame(t, s)
+
+	if err := s.ReadyRematch(code, ids[1]); err != usecase.ErrNoRematchVote {
+		t.Fatalf("err=%v", err)
+	}
+	if err := s.RequestRematch(code, ids[1], true); err != usecase.ErrNotHost {
+		t.Fatalf("err=%v", err)
+	}
+	if err := s.RequestRematch(code, ids[0], true); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids[1:] {
+		view, _ := s.ViewForPlayer(code, id)
+		if view.Status != domain.GameStatusFinished || view.Rematch == nil {
+			t.Fatalf("rematch started early: %+v", view)
+		}
+		if err := s.ReadyRematch(code, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	view, _ := s.ViewForPlayer(code, ids[0])
+	if view.Status != domain.GameStatusInGame || view.Rematch != nil || len(view.Players) != 3 {
+		t.Fatalf("view=%+v", view)
+	}
+	if _, err := s.Replay(code); err != nil {
+		t.Fatalf("previous game not archived: %v", err)
+	}
+}
+
+func TestSpectatorViewHidesPrivateState(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	for _, name := range []string{"B", "C"} {
+		if _, err := s.JoinLobby(created.LobbyCode, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Spectate(created.LobbyCode); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	view, _, _, err := s.SpectatorView(created.LobbyCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Spectators != 1 || view.You.ID != "" || len(view.You.Hand) != 0 {
+		t.Fatalf("view=%+v", view)
+	}
+	for _, p := range view.Players {
+		if p.Role != "" || len(p.Hand) != 0 {
+			t.Fatalf("private state leaked: %+v", p)
+		}
+	}
+}
+
+func TestMeetingClosesWhenEveryoneVoted(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	ids := []string{created.PlayerID}
+	for _, name := range []string{"B", "C"} {
+		joined, err := s.JoinLobby(created.LobbyCode, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, joined.PlayerID)
+	}
+	rules := domain.DefaultRules()
+	rules.MeetingsPerPlayer = 1
+	if err := s.UpdateRules(created.LobbyCode, created.PlayerID, rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CallMeeting(created.LobbyCode, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	view, _ := s.ViewForPlayer(created.LobbyCode, ids[0])
+	if view.Status != domain.GameStatusMeeting || view.MeetingDeadline == nil || view.TurnDeadline != nil {
+		t.Fatalf("status=%s deadline=%v", view.Status, view.MeetingDeadline)
+	}
+
+	for _, id := range ids {
+		if err := s.CastVote(created.LobbyCode, id, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	view, _ = s.ViewForPlayer(created.LobbyCode, ids[0])
+	if view.Status != domain.GameStatusInGame || view.LastMeeting == nil || view.MeetingDeadline != nil {
+		t.Fatalf("status=%s last=%+v", view.Status, view.LastMeeting)
+	}
+}
+
+func TestVersionGrowsOnlyOnChanges(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	version := func() uint64 {
+		t.Helper()
+		_, v, err := s.VersionedView(created.LobbyCode, created.PlayerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	v0 := version()
+	if version() != v0 {
+		t.Fatalf("reading the view changed the version")
+	}
+	joined, _ := s.JoinLobby(created.LobbyCode, "B")
+	v1 := version()
+	if v1 <= v0 {
+		t.Fatalf("join: version %d -> %d", v0, v1)
+	}
+	if err := s.StartGame(created.LobbyCode, joined.PlayerID); err != usecase.ErrNotHost {
+		t.Fatalf("err=%v", err)
+	}
+	if version() != v1 {
+		t.Fatalf("failed action changed the version")
+	}
+	ghost := true
+	if err := s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{GhostChat: &ghost}); err != nil {
+		t.Fatal(err)
+	}
+	if version() <= v1 {
+		t.Fatalf("settings change kept version %d", v1)
+	}
+}
+func TestMeetingDeadlineSurvivesCurrentPlayerLeaving(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	ids := []string{created.PlayerID}
+	for _, name := range []string{"B", "C", "D", "E"} {
+		joined, err := s.JoinLobby(created.LobbyCode, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, joined.PlayerID)
+	}
+	rules := domain.DefaultRules()
+	rules.MeetingsPerPlayer = 1
+	// Two impostors, so one player leaving never ends the game.
+	rules.ImpostorBrackets = []domain.ImpostorBracket{{MinPlayers: 0, Impostors: 2}}
+	if err := s.UpdateRules(created.LobbyCode, created.PlayerID, rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	view, _ := s.ViewForPlayer(created.LobbyCode, ids[0])
+	current := view.CurrentTurnPlayerID
+	watcher := ids[0]
+	if watcher == current {
+		watcher = ids[1]
+	}
+	if err := s.CallMeeting(created.LobbyCode, watcher); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := s.ViewForPlayer(created.LobbyCode, watcher)
+
+	time.Sleep(10 * time.Millisecond)
+	if err := s.LeaveLobby(created.LobbyCode, current); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := s.ViewForPlayer(created.LobbyCode, watcher)
+	if after.Status != domain.GameStatusMeeting || after.MeetingDeadline == nil {
+		t.Fatalf("status=%s deadline=%v", after.Status, after.MeetingDeadline)
+	}
+	if !after.MeetingDeadline.Equal(*before.MeetingDeadline) {
+		t.Fatalf("deadline moved from %v to %v", before.MeetingDeadline, after.MeetingDeadline)
+	}
+}
+
+func TestUpdateSettingsIsAllOrNothing(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	rules := domain.DefaultRules()
+	rules.AllowPass = true
+	err := s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{
+		Rules: &rules,
+		Deck:  []byte(`{"name":"x","cards":[{"type":"joker","fixed":1}]}`),
+	})
+	if !errors.Is(err, domain.ErrInvalidDeck) {
+		t.Fatalf("err=%v", err)
+	}
+	err = s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{Rules: &rules, DeckName: "nope"})
+	if err != usecase.ErrDeckNotFound {
+		t.Fatalf("err=%v", err)
+	}
+	view, _ := s.ViewForPlayer(created.LobbyCode, created.PlayerID)
+	if view.Rules.AllowPass || view.DeckName != "classic" {
+		t.Fatalf("partial update applied: pass=%v deck=%s", view.Rules.AllowPass, view.DeckName)
+	}
+
+	err = s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{Rules: &rules, DeckName: "tricks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, _ = s.ViewForPlayer(created.LobbyCode, created.PlayerID)
+	if !view.Rules.AllowPass || view.DeckName != "tricks" {
+		t.Fatalf("update not applied: pass=%v deck=%s", view.Rules.AllowPass, view.DeckName)
+	}
+}
+
+func TestMatchRunsThroughTheLobby(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	code, ids := created.LobbyCode, []string{created.PlayerID}
+	for _, name := range []string{"B", "C"} {
+		joined, _ := s.JoinLobby(code, name)
+		ids = append(ids, joined.PlayerID)
+	}
+	bad := domain.MatchConfig{Rounds: -1}
+	if err := s.UpdateSettings(code, created.PlayerID, usecase.LobbySettings{Match: &bad}); !errors.Is(err, domain.ErrInvalidMatch) {
+		t.Fatalf("err=%v", err)
+	}
+	cfg := domain.MatchConfig{Rounds: 2, GoodWinPoints: 1, ImpostorWinPoints: 2}
+	if err := s.UpdateSettings(code, created.PlayerID, usecase.LobbySettings{Match: &cfg}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartGame(code, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+
+	// A call-over with an empty chest is an impostor win.
+	want := map[string]int{}
+	playRound := func(round int) {
+		t.Helper()
+		view, _ := s.ViewForPlayer(code, ids[0])
+		if view.Status != domain.GameStatusInGame || view.Match == nil || view.Match.Round != round {
+			t.Fatalf("round %d: status=%s match=%+v", round, view.Status, view.Match)
+		}
+		caller := ""
+		for _, id := range ids {
+			v, _ := s.ViewForPlayer(code, id)
+			if v.You.Role == domain.RoleImpostor {
+				want[id] += cfg.ImpostorWinPoints
+			} else if caller == "" {
+				caller = id
+			}
+		}
+		if err := s.CallOver(code, caller); err != nil {
+			t.Fatal(err)
+		}
+	}
+	leaderboard := func() map[string]int {
+		view, _ := s.ViewForPlayer(code, ids[0])
+		got := map[string]int{}
+		for _, row := range view.Match.Leaderboard {
+			if row.Points > 0 {
+				got[row.PlayerID] = row.Points
+			}
+		}
+		return got
+	}
+	rematch := func() {
+		t.Helper()
+		if err := s.RequestRematch(code, ids[0], false); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range ids[1:] {
+			if err := s.ReadyRematch(code, id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	playRound(1)
+	if got := leaderboard(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after round 1: leaderboard=%v want %v", got, want)
+	}
+	// The rematch vote deals the next round straight away.
+	rematch()
+	playRound(2)
+	view, _ := s.ViewForPlayer(code, ids[0])
+	if !view.Match.Finished || view.Match.Round != 2 {
+		t.Fatalf("match=%+v", view.Match)
+	}
+	if got := leaderboard(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after round 2: leaderboard=%v want %v", got, want)
+	}
+
+	// A rematch after the last round starts a new match with the same config.
+	rematch()
+	view, _ = s.ViewForPlayer(code, ids[0])
+	if view.Status != domain.GameStatusLobby || view.Match.Round != 1 || view.Match.Rounds != 2 || len(leaderboard()) != 0 {
+		t.Fatalf("status=%s match=%+v", view.Status, view.Match)
+	}
+
+	single := domain.MatchConfig{}
+	if err := s.UpdateSettings(code, ids[0], usecase.LobbySettings{Match: &single}); err != nil {
+		t.Fatal(err)
+	}
+	if view, _ = s.ViewForPlayer(code, ids[0]); view.Match != nil {
+		t.Fatalf("match=%+v", view.Match)
+	}
+}
+
+func TestSpectatorDelayChangesDuringTheGame(t *testing.T) {
+	s := newService()
+	created, _ := s.CreateLobby("A")
+	for _, name := range []string{"B", "C"} {
+		if _, err := s.JoinLobby(created.LobbyCode, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+
+	delay := 10 * time.Second
+	if err := s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{SpectatorDelay: &delay}); err != nil {
+		t.Fatal(err)
+	}
+	tooLong := usecase.MaxSpectatorDelay + time.Second
+	if err := s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{SpectatorDelay: &tooLong}); err != usecase.ErrInvalidSpectatorDelay {
+		t.Fatalf("err=%v", err)
+	}
+	// Rules are locked once the game runs, and the delay goes with them.
+	rules := domain.DefaultRules()
+	zero := time.Duration(0)
+	err := s.UpdateSettings(created.LobbyCode, created.PlayerID, usecase.LobbySettings{Rules: &rules, SpectatorDelay: &zero})
+	if err != usecase.ErrLobbyAlreadyStarted {
+		t.Fatalf("err=%v", err)
+	}
+
+	view, _, got, err := s.SpectatorView(created.LobbyCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != delay || view.SpectatorDelaySeconds != 10 {
+		t.Fatalf("delay=%v view=%d", got, view.SpectatorDelaySeconds)
+	}
+}
+
+// stuckBrain wants to move on its turn but never makes a legal move.
+type stuckBrain struct{}
+
+func (stuckBrain) Observe(domain.GameView) {}
+
+func (stuckBrain) Act(v domain.GameView) (usecase.BotMove, bool) {
+	if v.Status != domain.GameStatusInGame || v.CurrentTurnPlayerID != v.You.ID {
+		return nil, false
+	}
+	return func(*domain.Game) error { return domain.ErrActionDisabled }, true
+}
+
+func TestBotWithoutLegalMoveTimesOut(t *testing.T) {
+	s := newService()
+	s.SetBotDelay(time.Millisecond)
+	created, _ := s.CreateLobby("A")
+	var bots []string
+	for i := 0; i < 2; i++ {
+		id, err := s.AddBot(created.LobbyCode, created.PlayerID, "", stuckBrain{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		bots = append(bots, id)
+	}
+	if err := s.StartGame(created.LobbyCode, created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		view, err := s.ViewForPlayer(created.LobbyCode, created.PlayerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		timedOut := 0
+		for _, p := range view.Players {
+			if p.ID != created.PlayerID && p.Timeouts > 0 {
+				timedOut++
+			}
+		}
+		if timedOut == len(bots) || view.Status != domain.GameStatusInGame {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("table stalled on %s: %+v", view.CurrentTurnPlayerID, view.Players)
+		}
+		if view.CurrentTurnPlayerID == created.PlayerID && len(view.You.Hand) > 0 {
+			target := ""
+			if view.You.Hand[0].Type == domain.CardTypeAccusation {
+				target = bots[0]
+			}
+			if err := s.PlayCard(created.LobbyCode, created.PlayerID, 0, target); err != nil {
+				t.Fatal(err)
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
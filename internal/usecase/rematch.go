@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"game-server/internal/domain"
+)
+
+// rematchVote is a pending rematch that every member must confirm.
+type rematchVote struct {
+	startImmediately bool
+	ready            map[string]bool
+}
+
+// RequestRematch opens a ready-vote to replay the finished game with the same
+// lobby and players. The host counts as ready. Once everybody is ready the game
+// goes back to the lobby, or straight into a new round if startImmediately.
+func (s *LobbyService) RequestRematch(code, hostID string, startImmediately bool) error {
+	return s.mutateAsHost(code, hostID, func(lobby *Lobby, g *domain.Game) error {
+		if g.Status != domain.GameStatusFinished {
+			return domain.ErrGameNotFinished
+		}
+		lobby.rematch = &rematchVote{startImmediately: startImmediately, ready: map[string]bool{hostID: true}}
+		return lobby.maybeRematch()
+	})
+}
+
+// ReadyRematch confirms the pending rematch for playerID.
+func (s *LobbyService) ReadyRematch(code, playerID string) error {
+	return s.mutate(code, func(lobby *Lobby, g *domain.Game) error {
+		if lobby.rematch == nil {
+			return ErrNoRematchVote
+		}
+		if !lobby.isMember(playerID) {
+			return ErrPlayerNotInLobby
+		}
+		lobby.rematch.ready[playerID] = true
+		return lobby.maybeRematch()
+	})
+}
+
+// maybeRematch resets the game once every member is ready; bots always are.
+// It must be called with the lock held.
+func (l *Lobby) maybeRematch() error {
+	for _, id := range l.playerOrder {
+		if !l.rematch.ready[id] && !l.isBot(id) {
+			return nil
+		}
+	}
+	doc, err := l.g.ReplayDocument(l.Code)
+	if err != nil {
+		return err
+	}
+	startImmediately := l.rematch.startImmediately
+	l.rematch = nil
+	l.lastReplay = &doc
+	l.forgetTeamChat()
+
+	if l.match != nil && !l.match.Finished {
+		// Next round of the match. Not enough players left is not an error
+		// for the vote; the lobby waits for the host to start instead.
+		if err := l.match.NextRound(); err != nil && l.g.Status != domain.GameStatusLobby {
+			return err
+		}
+		return nil
+	}
+	if err := l.g.Reset(); err != nil {
+		return err
+	}
+	if l.match != nil {
+		// The previous match is over; play a new one with the same settings.
+		l.match, _ = domain.NewMatch(l.g, l.match.Config)
+	}
+	if startImmediately {
+		_ = l.g.Start()
+	}
+	return nil
+}
+
+// rematchView must be called with the lock held.
+func (l *Lobby) rematchView() *domain.RematchView {
+	if l.rematch == nil {
+		return nil
+	}
+	v := &domain.RematchView{StartImmediately: l.rematch.startImmediately, Ready: []string{}}
+	for _, id := range l.playerOrder {
+		if l.rematch.ready[id] || l.isBot(id) {
+			v.Ready = append(v.Ready, id)
+		}
+	}
+	return v
+}
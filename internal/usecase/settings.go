@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"time"
+
+	"game-server/internal/domain"
+)
+
+// LobbySettings is a batch of host settings applied together. Nil and empty
+// fields are left unchanged.
+type LobbySettings struct {
+	Rules *domain.GameRules
+	// Match turns the lobby into a multi-round match; Rounds 0 switches back
+	// to single games.
+	Match *domain.MatchConfig
+	// Deck is a custom JSON deck spec; it takes precedence over DeckName.
+	Deck     []byte
+	DeckName string
+
+	SpectatorDelay *time.Duration
+	GhostChat      *bool
+}
+
+func (s LobbySettings) changesGame() bool {
+	return s.Rules != nil || s.Match != nil || len(s.Deck) > 0 || s.DeckName != ""
+}
+
+// UpdateSettings applies every setting in the batch or none of them. Host
+// only; rules, match and deck can only change before the game starts.
+func (s *LobbyService) UpdateSettings(code, playerID string, settings LobbySettings) error {
+	deck, err := s.deckFor(settings)
+	if err != nil {
+		return err
+	}
+	if d := settings.SpectatorDelay; d != nil && (*d < 0 || *d > MaxSpectatorDelay) {
+		return ErrInvalidSpectatorDelay
+	}
+
+	return s.mutateAsHost(code, playerID, func(lobby *Lobby, g *domain.Game) error {
+		if settings.changesGame() && g.Status != domain.GameStatusLobby {
+			return ErrLobbyAlreadyStarted
+		}
+		match := lobby.match
+		if cfg := settings.Match; cfg != nil {
+			match = nil
+			if cfg.Rounds != 0 {
+				m, err := domain.NewMatch(g, *cfg)
+				if err != nil {
+					return err
+				}
+				match = m
+			}
+		}
+		// The setters validate; put the old rules back if the deck is refused.
+		rules := g.Rules
+		if settings.Rules != nil {
+			if err := g.SetRules(*settings.Rules); err != nil {
+				return err
+			}
+		}
+		if deck != nil {
+			if err := g.SetDeck(*deck); err != nil {
+				g.Rules = rules
+				return err
+			}
+		}
+		lobby.match = match
+		if settings.SpectatorDelay != nil {
+			lobby.spectatorDelay = *settings.SpectatorDelay
+		}
+		if settings.GhostChat != nil {
+			lobby.ghostChat = *settings.GhostChat
+		}
+		return nil
+	})
+}
+
+// UpdateRules replaces the lobby's rule set before the game starts. Host only.
+func (s *LobbyService) UpdateRules(code, playerID string, rules domain.GameRules) error {
+	return s.UpdateSettings(code, playerID, LobbySettings{Rules: &rules})
+}
+
+// deckFor resolves the deck a batch asks for: an uploaded spec or a catalog
+// name. It returns nil when the batch leaves the deck alone.
+func (s *LobbyService) deckFor(settings LobbySettings) (*domain.DeckSpec, error) {
+	switch {
+	case len(settings.Deck) > 0:
+		d, err := domain.ParseDeckSpec(settings.Deck)
+		if err != nil {
+			return nil, err
+		}
+		return &d, nil
+	case settings.DeckName != "":
+		d, ok := s.decks.Get(settings.DeckName)
+		if !ok {
+			return nil, ErrDeckNotFound
+		}
+		return &d, nil
+	}
+	return nil, nil
+}
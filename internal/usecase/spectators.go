@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"time"
+
+	"game-server/internal/domain"
+)
+
+// MaxSpectatorDelay caps the spectator broadcast delay.
+const MaxSpectatorDelay = 5 * time.Minute
+
+// Spectate attaches a read-only viewer to the lobby.
+func (s *LobbyService) Spectate(code string) error {
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.WithLock(func(*domain.Game) error {
+		if lobby.closed {
+			return ErrLobbyNotFound
+		}
+		lobby.spectators++
+		lobby.bump()
+		lobby.lastActivity = time.Now().UTC()
+		return nil
+	})
+}
+
+// StopSpectating detaches a viewer added with Spectate.
+func (s *LobbyService) StopSpectating(code string) {
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return
+	}
+	_ = lobby.WithLock(func(*domain.Game) error {
+		if lobby.spectators > 0 {
+			lobby.spectators--
+			lobby.bump()
+		}
+		lobby.lastActivity = time.Now().UTC()
+		return nil
+	})
+}
+
+// SpectatorView returns the public view of the lobby with its version, and
+// how long it should be held back before being shown to spectators.
+func (s *LobbyService) SpectatorView(code string) (domain.GameView, uint64, time.Duration, error) {
+	lobby, ok := s.store.Get(code)
+	if !ok {
+		return domain.GameView{}, 0, 0, ErrLobbyNotFound
+	}
+	var (
+		view    domain.GameView
+		version uint64
+		delay   time.Duration
+	)
+	err := lobby.WithLock(func(g *domain.Game) error {
+		view = g.SpectatorView(code)
+		lobby.decorate(&view)
+		version, delay = lobby.version, lobby.spectatorDelay
+		return nil
+	})
+	return view, version, delay, err
+}
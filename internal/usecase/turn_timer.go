@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"game-server/internal/domain"
+)
+
+// armTurnTimer (re)starts the lobby's timer for the current turn or open
+// meeting, or stops it when the game is not running or has no time limit.
+// A timer already armed for the same turn or meeting keeps running.
+// It must be called with the lock held.
+func (s *LobbyService) armTurnTimer(lobby *Lobby, g *domain.Game) {
+	var (
+		key    string
+		d      time.Duration
+		expire func()
+	)
+	turn := g.Turn
+	switch {
+	case g.Status == domain.GameStatusMeeting:
+		// Keyed on the meeting itself: the turn can still advance during a
+		// meeting, e.g. when the current player leaves.
+		seq := g.Meeting.Seq
+		key = fmt.Sprintf("meeting:%d", seq)
+		d = time.Duration(g.Rules.MeetingVoteSeconds) * time.Second
+		expire = func() { s.meetingExpired(lobby.Code, seq) }
+	case g.Status == domain.GameStatusInGame && g.Rules.TurnTimeoutSeconds > 0:
+		playerID := g.CurrentPlayerID()
+		key = fmt.Sprintf("turn:%d:%s", turn, playerID)
+		d = time.Duration(g.Rules.TurnTimeoutSeconds) * time.Second
+		expire = func() { s.turnExpired(lobby.Code, turn, playerID) }
+	}
+	if key != "" && key == lobby.timerKey {
+		return
+	}
+	lobby.stopTimers()
+	if key == "" {
+		return
+	}
+
+	lobby.timerKey = key
+	lobby.turnDeadline = time.Now().UTC().Add(d)
+	lobby.turnTimer = time.AfterFunc(d, expire)
+}
+
+func (s *LobbyService) turnExpired(code string, turn int, playerID string) {
+	err := s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		if g.Status != domain.GameStatusInGame || g.Turn != turn || g.CurrentPlayerID() != playerID {
+			return errStaleTimer
+		}
+		return g.HandleTurnTimeout(playerID)
+	})
+	if err == nil {
+		s.notify(code)
+	}
+}
+
+// meetingExpired closes the meeting with whatever ballots were cast.
+func (s *LobbyService) meetingExpired(code string, seq int) {
+	err := s.mutate(code, func(_ *Lobby, g *domain.Game) error {
+		if g.Status != domain.GameStatusMeeting || g.Meeting.Seq != seq {
+			return errStaleTimer
+		}
+		return g.CloseMeeting()
+	})
+	if err == nil {
+		s.notify(code)
+	}
+}